@@ -0,0 +1,88 @@
+package messageformat
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// These are escaped on purpose: they are invisible when reading the source.
+const (
+	// firstStrongIsolate is U+2068 FIRST STRONG ISOLATE.
+	firstStrongIsolate = "\u2068"
+	// popDirectionalIsolate is U+2069 POP DIRECTIONAL ISOLATE.
+	popDirectionalIsolate = "\u2069"
+)
+
+// rtlScripts are the scripts written from right to left.
+var rtlScripts = map[string]struct{}{
+	"Adlm": struct{}{},
+	"Arab": struct{}{},
+	"Hebr": struct{}{},
+	"Mand": struct{}{},
+	"Mend": struct{}{},
+	"Nkoo": struct{}{},
+	"Rohg": struct{}{},
+	"Samr": struct{}{},
+	"Syrc": struct{}{},
+	"Thaa": struct{}{},
+	"Yezi": struct{}{},
+}
+
+// tagDirection is the direction of the likely script of tag.
+func tagDirection(tag language.Tag) bidi.Direction {
+	script, _ := tag.Script()
+	if _, ok := rtlScripts[script.String()]; ok {
+		return bidi.RightToLeft
+	}
+	return bidi.LeftToRight
+}
+
+// stringDirection is the direction of the first strong character of s.
+func stringDirection(s string) bidi.Direction {
+	for len(s) > 0 {
+		p, size := bidi.LookupString(s)
+		switch p.Class() {
+		case bidi.L:
+			return bidi.LeftToRight
+		case bidi.R, bidi.AL:
+			return bidi.RightToLeft
+		}
+		if size <= 0 {
+			break
+		}
+		s = s[size:]
+	}
+	return bidi.Neutral
+}
+
+// bidiIsolateValue isolates value like bidiIsolate.
+// A value of a named string type, such as template.HTML, keeps its type,
+// so that html/template does not escape it.
+// Other values are converted to string only when they are isolated.
+func bidiIsolateValue(tag language.Tag, value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.String {
+		return reflect.ValueOf(bidiIsolate(tag, rv.String())).Convert(rv.Type()).Interface()
+	}
+	if value == nil {
+		return value
+	}
+	s := fmt.Sprint(value)
+	if isolated := bidiIsolate(tag, s); isolated != s {
+		return isolated
+	}
+	return value
+}
+
+// bidiIsolate wraps s in FSI and PDI if the direction of s differs from
+// the direction of tag, so that s does not reorder the text around it.
+func bidiIsolate(tag language.Tag, s string) string {
+	dir := stringDirection(s)
+	if dir == bidi.Neutral || dir == tagDirection(tag) {
+		return s
+	}
+	return firstStrongIsolate + s + popDirectionalIsolate
+}
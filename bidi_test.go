@@ -0,0 +1,35 @@
+package messageformat
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestBidiIsolate(t *testing.T) {
+	test := func(lang string, s string, expected string) {
+		actual := bidiIsolate(language.Make(lang), s)
+		if actual != expected {
+			t.Errorf("%v %q: %q != %q\n", lang, s, actual, expected)
+		}
+	}
+
+	// Same direction is not isolated.
+	test("en", "John", "John")
+	test("he", "יוחנן", "יוחנן")
+	test("ar", "يوحنا", "يوحنا")
+
+	// Different direction is isolated.
+	test("he", "John", "\u2068John\u2069")
+	test("ar", "John!", "\u2068John!\u2069")
+	test("fa", "John", "\u2068John\u2069")
+	test("en", "יוחנן", "\u2068יוחנן\u2069")
+
+	// The first strong character decides.
+	test("he", "123 John", "\u2068123 John\u2069")
+	test("en", "123 יוחנן John", "\u2068123 יוחנן John\u2069")
+
+	// No strong character is not isolated.
+	test("he", "123", "123")
+	test("he", "", "")
+}
@@ -0,0 +1,171 @@
+package messageformat
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// splitChoiceStyle splits the style of a choice argument at |.
+// | inside nested arguments or quoted text does not split.
+func splitChoiceStyle(style string) []string {
+	var out []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(style); i++ {
+		switch ch := style[i]; {
+		case ch == '\'':
+			quoted = !quoted
+		case quoted:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+		case ch == '|' && depth == 0:
+			out = append(out, style[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, style[start:])
+}
+
+// parseChoiceLimit parses the limit of a choice clause,
+// which is a number, ∞ or -∞.
+func parseChoiceLimit(s string) (float64, error) {
+	switch s {
+	case "∞", "+∞":
+		return math.Inf(1), nil
+	case "-∞":
+		return math.Inf(-1), nil
+	}
+	// strconv.ParseFloat also accepts Inf, NaN and hexadecimal.
+	if s != "" && strings.Trim(s, "+-.0123456789eE") == "" {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unexpected choice limit: %v", s)
+}
+
+// choiceNumber converts value of a choice argument to float64.
+func choiceNumber(value interface{}) (float64, error) {
+	decimal, _, err := numberDecimal(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(decimal, 64)
+}
+
+// choiceBelow reports whether number is below the limit of a choice clause,
+// that is the number selects a preceding clause.
+func choiceBelow(number float64, limit float64, strict bool) bool {
+	if strict {
+		return !(number > limit)
+	}
+	return !(number >= limit)
+}
+
+// choiceClauseIndex returns the index of the clause selected by number,
+// which is the last clause whose limit the number reaches.
+// The first clause is selected for numbers below all limits, and for NaN.
+func choiceClauseIndex(clauses []ChoiceClause, number float64) int {
+	for i := 1; i < len(clauses); i++ {
+		if choiceBelow(number, clauses[i].Limit, clauses[i].Strict) {
+			return i - 1
+		}
+	}
+	return len(clauses) - 1
+}
+
+// rewriteChoice rewrites choice arguments in nodes to equivalent plural arguments,
+// recursively. Choice arguments that cannot be rewritten are kept.
+func rewriteChoice(nodes []Node) []Node {
+	out := make([]Node, len(nodes))
+	for i, node := range nodes {
+		switch n := node.(type) {
+		case ChoiceArgNode:
+			clauses := make([]ChoiceClause, len(n.Clauses))
+			for j, clause := range n.Clauses {
+				clause.Nodes = rewriteChoice(clause.Nodes)
+				clauses[j] = clause
+			}
+			n.Clauses = clauses
+			if plural, ok := choiceToPlural(n); ok {
+				node = plural
+			} else {
+				node = n
+			}
+		case SelectArgNode:
+			clauses := make([]SelectClause, len(n.Clauses))
+			for j, clause := range n.Clauses {
+				clause.Nodes = rewriteChoice(clause.Nodes)
+				clauses[j] = clause
+			}
+			n.Clauses = clauses
+			node = n
+		case PluralArgNode:
+			clauses := make([]PluralClause, len(n.Clauses))
+			for j, clause := range n.Clauses {
+				clause.Nodes = rewriteChoice(clause.Nodes)
+				clauses[j] = clause
+			}
+			n.Clauses = clauses
+			node = n
+		case MarkupNode:
+			n.Nodes = rewriteChoice(n.Nodes)
+			node = n
+		}
+		out[i] = node
+	}
+	return out
+}
+
+// choiceToPlural rewrites node to a plural argument with explicit values,
+// assuming the argument is a non-negative integer, such as a count.
+// It is possible when every clause but the last selects at most one integer,
+// for example `0#no files|1#one file|1<{0} files` is
+// `=0 {no files} =1 {one file} other {{0} files}`.
+func choiceToPlural(node ChoiceArgNode) (out PluralArgNode, ok bool) {
+	// The integers selected by clause i are from low to the high of clause i.
+	low := 0.0
+	for i, clause := range node.Clauses {
+		if i > 0 {
+			low = math.Max(low, choiceLowestInteger(clause.Limit, clause.Strict))
+		}
+
+		if i == len(node.Clauses)-1 {
+			if math.IsInf(low, 1) {
+				return
+			}
+			out.Clauses = append(out.Clauses, PluralClause{Keyword: "other", Nodes: clause.Nodes})
+			break
+		}
+
+		next := node.Clauses[i+1]
+		// The clause selects none of the integers.
+		high := choiceLowestInteger(next.Limit, next.Strict) - 1
+		if low > high {
+			continue
+		}
+		// The explicit value must be an int.
+		if low != high || low > math.MaxInt32 {
+			return
+		}
+		out.Clauses = append(out.Clauses, PluralClause{ExplicitValue: int(low), Nodes: clause.Nodes})
+	}
+
+	out.Arg = node.Arg
+	out.Kind = "plural"
+	ok = true
+	return
+}
+
+// choiceLowestInteger returns the lowest integer reaching the limit of a choice clause.
+func choiceLowestInteger(limit float64, strict bool) float64 {
+	if strict {
+		return math.Floor(limit) + 1
+	}
+	return math.Ceil(limit)
+}
@@ -0,0 +1,168 @@
+package messageformat
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSplitChoiceStyle(t *testing.T) {
+	test := func(style string, expected []string) {
+		actual := splitChoiceStyle(style)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%q: %q != %q\n", style, actual, expected)
+		}
+	}
+
+	test("0#no files", []string{"0#no files"})
+	test("0#no files|1#one file|1<{0} files", []string{"0#no files", "1#one file", "1<{0} files"})
+	test("0#a|1#{1, select, a {x|y} other {z}}", []string{"0#a", "1#{1, select, a {x|y} other {z}}"})
+	test("0#'|'|1#b", []string{"0#'|'", "1#b"})
+	test("0#a|", []string{"0#a", ""})
+}
+
+func TestParseChoiceLimit(t *testing.T) {
+	test := func(s string, expected float64) {
+		actual, err := parseChoiceLimit(s)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%q: %v != %v\n", s, actual, expected)
+		}
+	}
+
+	test("0", 0)
+	test("1.5", 1.5)
+	test("-1", -1)
+	test("1e3", 1000)
+	test("∞", math.Inf(1))
+	test("-∞", math.Inf(-1))
+
+	testError := func(s string) {
+		_, err := parseChoiceLimit(s)
+		if err == nil || err.Error() != "unexpected choice limit: "+s {
+			t.Errorf("%q: unexpected error: %v\n", s, err)
+		}
+	}
+
+	testError("")
+	testError("one")
+	testError("Inf")
+	testError("NaN")
+	testError("0x10")
+	testError("1-")
+}
+
+func TestChoiceClauseIndex(t *testing.T) {
+	clauses := []ChoiceClause{
+		{Limit: 0},
+		{Limit: 1},
+		{Limit: 1, Strict: true},
+	}
+	test := func(number float64, expected int) {
+		actual := choiceClauseIndex(clauses, number)
+		if actual != expected {
+			t.Errorf("%v: %v != %v\n", number, actual, expected)
+		}
+	}
+
+	test(-1, 0)
+	test(0, 0)
+	test(0.5, 0)
+	test(1, 1)
+	test(1.5, 2)
+	test(2, 2)
+	test(math.Inf(1), 2)
+	test(math.NaN(), 0)
+}
+
+func TestRewriteChoice(t *testing.T) {
+	test := func(pattern string, expected []Node) {
+		actual, err := Options{RewriteChoice: true}.Parse(pattern)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%v: %#v != %#v\n", pattern, actual, expected)
+		}
+	}
+
+	test("{0, choice, 0#no files|1#one file|1<{0} files}", []Node{
+		TextNode{},
+		PluralArgNode{
+			Arg:  Argument{Index: 0},
+			Kind: "plural",
+			Clauses: []PluralClause{
+				{ExplicitValue: 0, Nodes: []Node{TextNode{"no files"}}},
+				{ExplicitValue: 1, Nodes: []Node{TextNode{"one file"}}},
+				{Keyword: "other", Nodes: []Node{TextNode{}, NoneArgNode{Arg: Argument{Index: 0}}, TextNode{" files"}}},
+			},
+		},
+		TextNode{},
+	})
+
+	// The clause of negative numbers selects no non-negative integers.
+	// 1≤ is the same as 1#, and 1.5< selects integers from 2.
+	test("{n, choice, -∞#none|0#zero|1≤one|1.5<many}", []Node{
+		TextNode{},
+		PluralArgNode{
+			Arg:  Argument{Name: "n"},
+			Kind: "plural",
+			Clauses: []PluralClause{
+				{ExplicitValue: 0, Nodes: []Node{TextNode{"zero"}}},
+				{ExplicitValue: 1, Nodes: []Node{TextNode{"one"}}},
+				{Keyword: "other", Nodes: []Node{TextNode{"many"}}},
+			},
+		},
+		TextNode{},
+	})
+
+	// Nested choice arguments are rewritten too.
+	test("{g, select, other {{n, choice, 0#none|1#some}}}", []Node{
+		TextNode{},
+		SelectArgNode{
+			Arg: Argument{Name: "g"},
+			Clauses: []SelectClause{
+				{Keyword: "other", Nodes: []Node{
+					TextNode{},
+					PluralArgNode{
+						Arg:  Argument{Name: "n"},
+						Kind: "plural",
+						Clauses: []PluralClause{
+							{ExplicitValue: 0, Nodes: []Node{TextNode{"none"}}},
+							{Keyword: "other", Nodes: []Node{TextNode{"some"}}},
+						},
+					},
+					TextNode{},
+				}},
+			},
+		},
+		TextNode{},
+	})
+
+	// The second clause selects 1 and 2, which no explicit value does.
+	test("{n, choice, 0#none|1#few|3#many}", []Node{
+		TextNode{},
+		ChoiceArgNode{
+			Arg: Argument{Name: "n"},
+			Clauses: []ChoiceClause{
+				{Limit: 0, Nodes: []Node{TextNode{"none"}}},
+				{Limit: 1, Nodes: []Node{TextNode{"few"}}},
+				{Limit: 3, Nodes: []Node{TextNode{"many"}}},
+			},
+		},
+		TextNode{},
+	})
+
+	// The last clause selects no integers.
+	test("{n, choice, 0#finite|∞#infinite}", []Node{
+		TextNode{},
+		ChoiceArgNode{
+			Arg: Argument{Name: "n"},
+			Clauses: []ChoiceClause{
+				{Limit: 0, Nodes: []Node{TextNode{"finite"}}},
+				{Limit: math.Inf(1), Nodes: []Node{TextNode{"infinite"}}},
+			},
+		},
+		TextNode{},
+	})
+}
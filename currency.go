@@ -0,0 +1,74 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Currency is the value of `{Argument, currency [, style]}`.
+// Value can be any supported numeric type.
+// Decimal strings such as "1234.50", Decimal and the math/big types
+// are formatted exactly, so use them instead of float64 for money.
+// Code is the ISO 4217 currency code, such as "EUR".
+type Currency struct {
+	Value interface{}
+	Code  string
+}
+
+// currencyUnitWidth resolves the style of a currency argument to the unit width stem.
+func currencyUnitWidth(style string) (string, error) {
+	switch style {
+	case "", "symbol":
+		return "unit-width-short", nil
+	case "narrow":
+		return "unit-width-narrow", nil
+	case "code":
+		return "unit-width-iso-code", nil
+	case "name":
+		return "unit-width-full-name", nil
+	}
+	return "", fmt.Errorf("unexpected style: %v", style)
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func currencyValue(value interface{}) (*Currency, bool) {
+	switch v := value.(type) {
+	case Currency:
+		return &v, true
+	case *Currency:
+		return v, v != nil
+	}
+	return nil, false
+}
+
+// formatCurrency formats c with the style of a currency argument.
+// The fraction digits are those of the currency unless c.Value is a Number.
+func formatCurrency(tag language.Tag, style string, c Currency) (out string, err error) {
+	width, err := currencyUnitWidth(style)
+	if err != nil {
+		return
+	}
+	code := strings.ToUpper(c.Code)
+	if !isCurrencyCode(code) {
+		err = fmt.Errorf("invalid currency code: %v", c.Code)
+		return
+	}
+	decimal, precision, err := numberDecimal(c.Value)
+	if err != nil {
+		return
+	}
+	return formatNumber(tag, "::currency/"+code+" "+width, decimal, precision)
+}
@@ -0,0 +1,43 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestCurrencyUnitWidth(t *testing.T) {
+	test := func(style string, expected string) {
+		actual, err := currencyUnitWidth(style)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%q: %q != %q\n", style, actual, expected)
+		}
+	}
+
+	test("", "unit-width-short")
+	test("symbol", "unit-width-short")
+	test("narrow", "unit-width-narrow")
+	test("code", "unit-width-iso-code")
+	test("name", "unit-width-full-name")
+
+	_, err := currencyUnitWidth("accounting")
+	if err == nil || err.Error() != "unexpected style: accounting" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestIsCurrencyCode(t *testing.T) {
+	test := func(code string, expected bool) {
+		actual := isCurrencyCode(code)
+		if actual != expected {
+			t.Errorf("%q: %v != %v\n", code, actual, expected)
+		}
+	}
+
+	test("EUR", true)
+	test("XXX", true)
+	test("eur", false)
+	test("EURO", false)
+	test("E1R", false)
+	test("", false)
+}
@@ -0,0 +1,43 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateInterval is the value of `{Argument, dateinterval, style}`.
+// A pointer to DateInterval is also accepted.
+type DateInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// dateIntervalSkeleton resolves the style of a dateinterval argument to a date skeleton.
+// The styles short, medium, long and full resemble the date styles of the same names.
+func dateIntervalSkeleton(style string) (string, error) {
+	switch style {
+	case "short":
+		return "yMd", nil
+	case "medium":
+		return "yMMMd", nil
+	case "long":
+		return "yMMMMd", nil
+	case "full":
+		return "yMMMMEEEEd", nil
+	}
+	if strings.HasPrefix(style, "::") && len(style) > 2 {
+		return style[2:], nil
+	}
+	return "", fmt.Errorf("unexpected style: %v", style)
+}
+
+func dateIntervalValue(value interface{}) (*DateInterval, bool) {
+	switch v := value.(type) {
+	case DateInterval:
+		return &v, true
+	case *DateInterval:
+		return v, v != nil
+	}
+	return nil, false
+}
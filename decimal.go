@@ -0,0 +1,192 @@
+package messageformat
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimal is implemented by arbitrary-precision decimal types.
+// DecimalString must return the exact value in plain decimal notation,
+// such as "-1234.50". Trailing zeros in the fraction are significant,
+// so "1.50" is formatted as "1.50" and has the plural operand v = 2.
+type Decimal interface {
+	DecimalString() string
+}
+
+// isDecimalString reports whether s is in `-?[0-9]+(\.[0-9]+)?` format.
+func isDecimalString(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	idx := strings.IndexByte(s, '.')
+	if idx == -1 {
+		return isDigits(s)
+	}
+	return isDigits(s[:idx]) && isDigits(s[idx+1:])
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// bigDecimalString returns the exact value of value in plain decimal notation.
+// ok is false if value is neither of an arbitrary-precision type nor Number.
+func bigDecimalString(value interface{}) (out string, ok bool, err error) {
+	switch v := value.(type) {
+	case Number:
+		ok = true
+		out, err = v.decimalString()
+	case *big.Int:
+		ok = true
+		if v == nil {
+			err = fmt.Errorf("unsupported argument type: %T", value)
+			return
+		}
+		out = v.String()
+	case *big.Float:
+		ok = true
+		if v == nil || v.IsInf() {
+			err = fmt.Errorf("unsupported number: %v", v)
+			return
+		}
+		out = v.Text('f', -1)
+	case *big.Rat:
+		ok = true
+		if v == nil {
+			err = fmt.Errorf("unsupported argument type: %T", value)
+			return
+		}
+		out, err = ratDecimalString(v, -1)
+	case Decimal:
+		ok = true
+		out = v.DecimalString()
+		if !isDecimalString(out) {
+			err = fmt.Errorf("invalid decimal: %q", out)
+			return
+		}
+	}
+	return
+}
+
+// ratDecimalString returns r in plain decimal notation with fractionDigits digits in the fraction.
+// If fractionDigits is negative, r is formatted exactly,
+// and it is an error if r has no terminating decimal expansion.
+func ratDecimalString(r *big.Rat, fractionDigits int) (out string, err error) {
+	if fractionDigits >= 0 {
+		return r.FloatString(fractionDigits), nil
+	}
+
+	// r terminates if and only if its denominator has no prime factors other than 2 and 5.
+	denom := new(big.Int).Set(r.Denom())
+	twos := 0
+	fives := 0
+	two := big.NewInt(2)
+	five := big.NewInt(5)
+	mod := new(big.Int)
+	for {
+		q, m := new(big.Int).QuoRem(denom, two, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		denom = q
+		twos++
+	}
+	for {
+		q, m := new(big.Int).QuoRem(denom, five, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		denom = q
+		fives++
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		err = fmt.Errorf("%v has no exact decimal representation", r.RatString())
+		return
+	}
+
+	fractionDigits = twos
+	if fives > fractionDigits {
+		fractionDigits = fives
+	}
+	return r.FloatString(fractionDigits), nil
+}
+
+// compactDecimalString returns s in compact notation, such as 1.2c6, in plain decimal notation.
+// The exponent is introduced by c, or e which is its deprecated synonym in CLDR,
+// so 1.2e6 is the same as 1.2c6.
+// ok is false if s is not in compact notation.
+func compactDecimalString(s string) (out string, ok bool) {
+	if !strings.ContainsAny(s, "ce") {
+		return
+	}
+	negative := strings.HasPrefix(s, "-")
+	plain, _, err := splitCompact(strings.TrimPrefix(s, "-"))
+	if err != nil || !isDecimalString(plain) {
+		return
+	}
+	if negative {
+		plain = "-" + plain
+	}
+	return plain, true
+}
+
+// parseDecimal parses s in any format accepted by big.Rat or in compact notation,
+// and reports the number of digits in the fraction of s.
+func parseDecimal(s string) (r *big.Rat, fractionDigits int, err error) {
+	if strings.ContainsAny(s, "ce") {
+		plain, ok := compactDecimalString(s)
+		if !ok {
+			err = fmt.Errorf("invalid number: %q", s)
+			return
+		}
+		s = plain
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "/") {
+		err = fmt.Errorf("invalid number: %q", s)
+		return
+	}
+	if isDecimalString(s) {
+		if idx := strings.IndexByte(s, '.'); idx != -1 {
+			fractionDigits = len(s) - idx - 1
+		}
+		return
+	}
+	// s is in scientific notation, such as 1.5e3.
+	out, err := ratDecimalString(r, -1)
+	if err != nil {
+		return
+	}
+	if idx := strings.IndexByte(out, '.'); idx != -1 {
+		fractionDigits = len(out) - idx - 1
+	}
+	return
+}
+
+// subtractDecimal subtracts offset from the number s,
+// keeping the number of digits in the fraction of s.
+func subtractDecimal(s string, offset int) (out string, err error) {
+	r, fractionDigits, err := parseDecimal(s)
+	if err != nil {
+		return
+	}
+	r.Sub(r, new(big.Rat).SetInt64(int64(offset)))
+	return r.FloatString(fractionDigits), nil
+}
+
+// equalDecimal reports whether the number s equals n.
+func equalDecimal(s string, n int) (equal bool, err error) {
+	r, _, err := parseDecimal(s)
+	if err != nil {
+		return
+	}
+	return r.Cmp(new(big.Rat).SetInt64(int64(n))) == 0, nil
+}
@@ -0,0 +1,127 @@
+package messageformat
+
+import (
+	"math/big"
+	"testing"
+)
+
+type testDecimal string
+
+func (d testDecimal) DecimalString() string {
+	return string(d)
+}
+
+func TestBigDecimalString(t *testing.T) {
+	test := func(value interface{}, expected string) {
+		actual, ok, err := bigDecimalString(value)
+		if !ok {
+			t.Errorf("%#v: not ok\n", value)
+		} else if err != nil {
+			t.Errorf("%#v: err: %v\n", value, err)
+		} else if actual != expected {
+			t.Errorf("%#v: %q != %q\n", value, actual, expected)
+		}
+	}
+
+	i, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	test(i, "123456789012345678901234567890")
+	test(big.NewInt(-42), "-42")
+
+	f, _ := new(big.Float).SetPrec(200).SetString("12345678901234567890.125")
+	test(f, "12345678901234567890.125")
+
+	test(big.NewRat(1, 8), "0.125")
+	test(big.NewRat(-3, 2), "-1.5")
+	test(big.NewRat(10, 1), "10")
+
+	test(testDecimal("1.50"), "1.50")
+	test(testDecimal("-0.000000000000000000001"), "-0.000000000000000000001")
+
+	testError := func(value interface{}, expected string) {
+		_, ok, err := bigDecimalString(value)
+		if !ok {
+			t.Errorf("%#v: not ok\n", value)
+		} else if err == nil {
+			t.Errorf("%#v: expected error\n", value)
+		} else if err.Error() != expected {
+			t.Errorf("%#v: %v != %v\n", value, err.Error(), expected)
+		}
+	}
+
+	testError(big.NewRat(1, 3), "1/3 has no exact decimal representation")
+	testError(testDecimal("1e3"), `invalid decimal: "1e3"`)
+	testError(new(big.Float).SetInf(false), "unsupported number: +Inf")
+	testError((*big.Int)(nil), "unsupported argument type: *big.Int")
+
+	_, ok, _ := bigDecimalString(42)
+	if ok {
+		t.Errorf("42 is not arbitrary-precision\n")
+	}
+}
+
+func TestSubtractDecimal(t *testing.T) {
+	test := func(s string, offset int, expected string) {
+		actual, err := subtractDecimal(s, offset)
+		if err != nil {
+			t.Errorf("%v: err: %v\n", s, err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", s, actual, expected)
+		}
+	}
+
+	test("3", 1, "2")
+	test("3.5", 1, "2.5")
+	test("1.30", 1, "0.30")
+	test("0", 1, "-1")
+	test("1e3", 1, "999")
+	test("1.2c6", 0, "1200000")
+	test("1.2e6", 0, "1200000")
+	test("-1.25c1", 1, "-13.5")
+	test("99999999999999999999.000000000000000000001", 1, "99999999999999999998.000000000000000000001")
+
+	_, err := subtractDecimal("a", 1)
+	if err == nil {
+		t.Errorf("expected error\n")
+	}
+
+	// e is the compact exponent, which must not be negative.
+	_, err = subtractDecimal("1e-3", 1)
+	if err == nil || err.Error() != `invalid number: "1e-3"` {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestCompactDecimalString(t *testing.T) {
+	test := func(s string, expected string, expectedOK bool) {
+		actual, ok := compactDecimalString(s)
+		if actual != expected || ok != expectedOK {
+			t.Errorf("%v: %q %v != %q %v\n", s, actual, ok, expected, expectedOK)
+		}
+	}
+
+	test("1.2c6", "1200000", true)
+	test("1.2e6", "1200000", true)
+	test("-1.2c6", "-1200000", true)
+	test("1.2345c2", "123.45", true)
+	test("1200000", "", false)
+	test("1e-3", "", false)
+	test("ice", "", false)
+}
+
+func TestEqualDecimal(t *testing.T) {
+	test := func(s string, n int, expected bool) {
+		actual, err := equalDecimal(s, n)
+		if err != nil {
+			t.Errorf("%v: err: %v\n", s, err)
+		} else if actual != expected {
+			t.Errorf("%v: %v != %v\n", s, actual, expected)
+		}
+	}
+
+	test("1", 1, true)
+	test("1.00", 1, true)
+	test("1.0000000000000000001", 1, false)
+	test("-1", 1, false)
+	test("1c6", 1000000, true)
+	test("1e6", 1000000, true)
+}
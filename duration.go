@@ -0,0 +1,251 @@
+package messageformat
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+// durationUnits are the units of durations from the largest.
+// Their names are the units of number skeletons.
+var durationUnits = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+	{"millisecond", time.Millisecond},
+}
+
+const (
+	durationUnitDay = iota
+	durationUnitHour
+	durationUnitMinute
+	durationUnitSecond
+	durationUnitMillisecond
+)
+
+type durationStyle struct {
+	// Width is one of long, short, narrow and digital.
+	Width string
+	// Largest and Smallest are indices of durationUnits.
+	Largest  int
+	Smallest int
+	// RuleSet is the rule set of RuleBasedNumberFormat, such as %with-words.
+	RuleSet string
+}
+
+func durationUnitIndex(name string) (int, bool) {
+	for i, u := range durationUnits {
+		if u.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// parseDurationStyle parses the style of a duration argument,
+// such as "long largest/hour smallest/minute" or "%with-words".
+// The default is short from day to second, or digital from hour to second.
+func parseDurationStyle(style string) (out durationStyle, err error) {
+	out.Width = "short"
+	largest, smallest := -1, -1
+	for _, word := range strings.Fields(style) {
+		switch {
+		case word == "long" || word == "short" || word == "narrow" || word == "digital":
+			out.Width = word
+		case isRuleSet(word):
+			out.RuleSet = word
+		case strings.HasPrefix(word, "largest/"):
+			var ok bool
+			largest, ok = durationUnitIndex(strings.TrimPrefix(word, "largest/"))
+			if !ok {
+				err = fmt.Errorf("unexpected style: %v", word)
+				return
+			}
+		case strings.HasPrefix(word, "smallest/"):
+			var ok bool
+			smallest, ok = durationUnitIndex(strings.TrimPrefix(word, "smallest/"))
+			if !ok {
+				err = fmt.Errorf("unexpected style: %v", word)
+				return
+			}
+		default:
+			err = fmt.Errorf("unexpected style: %v", word)
+			return
+		}
+	}
+
+	out.Largest = durationUnitDay
+	out.Smallest = durationUnitSecond
+	if out.Width == "digital" {
+		out.Largest = durationUnitHour
+	}
+	if largest != -1 {
+		out.Largest = largest
+	}
+	if smallest != -1 {
+		out.Smallest = smallest
+	}
+
+	if out.Largest > out.Smallest {
+		err = fmt.Errorf("largest unit %v is smaller than smallest unit %v", durationUnits[out.Largest].Name, durationUnits[out.Smallest].Name)
+		return
+	}
+	// The digital form is hours, minutes and seconds, such as 1:05:00.
+	if out.Width == "digital" && (out.Largest < durationUnitHour || out.Smallest > durationUnitSecond) {
+		err = fmt.Errorf("digital duration must be in hours, minutes and seconds: %v", style)
+		return
+	}
+	return
+}
+
+// durationFields splits the absolute value of d into the units from largest to smallest.
+// d is rounded to the smallest unit.
+func durationFields(d time.Duration, largest int, smallest int) (negative bool, fields []int64) {
+	negative = d < 0
+	// Round before taking the absolute value,
+	// so that the minimum time.Duration does not overflow.
+	d = d.Round(durationUnits[smallest].Duration)
+	// Divide before negating for the same reason.
+	for i := largest; i <= smallest; i++ {
+		unit := durationUnits[i].Duration
+		field := d / unit
+		d -= field * unit
+		if field < 0 {
+			field = -field
+		}
+		fields = append(fields, int64(field))
+	}
+	return
+}
+
+func durationValue(value interface{}) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case *time.Duration:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return 0, false
+}
+
+// formatDurationArgument formats value of a duration argument.
+// A time.Duration is formatted with formatDuration,
+// unless the style has a rule set.
+// A number is seconds formatted with RuleBasedNumberFormat, like ICU does.
+// ok is false if value is neither a time.Duration nor a number.
+func formatDurationArgument(tag language.Tag, style string, value interface{}) (out string, ok bool, err error) {
+	s, err := parseDurationStyle(style)
+	if err != nil {
+		return
+	}
+
+	var decimal string
+	if d, isDuration := durationValue(value); isDuration {
+		if s.RuleSet == "" {
+			out, err = formatDuration(tag, style, d)
+			return out, true, err
+		}
+		decimal = durationSeconds(d)
+	} else {
+		var decimalErr error
+		decimal, _, decimalErr = numberDecimal(value)
+		if decimalErr != nil {
+			return
+		}
+	}
+
+	out, err = formatRuleBasedNumber(tag, "duration", s.RuleSet, decimal)
+	return out, true, err
+}
+
+// durationSeconds returns d in seconds in plain decimal notation.
+func durationSeconds(d time.Duration) string {
+	s := new(big.Rat).SetFrac64(int64(d), int64(time.Second)).FloatString(9)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// formatDuration formats d with the style of a duration argument.
+// Each unit is formatted as a measure, and the measures are joined with
+// the unit list pattern of the locale, such as "1 hr, 5 min".
+// The zero units are omitted, unless all units are zero.
+func formatDuration(tag language.Tag, style string, d time.Duration) (out string, err error) {
+	s, err := parseDurationStyle(style)
+	if err != nil {
+		return
+	}
+
+	negative, fields := durationFields(d, s.Largest, s.Smallest)
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+
+	if s.Width == "digital" {
+		return formatDigitalDuration(tag, sign, fields)
+	}
+
+	var unitWidth string
+	var listWidth icu4c.ListWidth
+	switch s.Width {
+	case "long":
+		unitWidth = "unit-width-full-name"
+		listWidth = icu4c.ListWidthWide
+	case "short":
+		unitWidth = "unit-width-short"
+		listWidth = icu4c.ListWidthShort
+	case "narrow":
+		unitWidth = "unit-width-narrow"
+		listWidth = icu4c.ListWidthNarrow
+	}
+
+	var measures []string
+	for i, field := range fields {
+		unit := s.Largest + i
+		if field == 0 && !(len(measures) == 0 && unit == s.Smallest) {
+			continue
+		}
+		decimal := strconv.FormatInt(field, 10)
+		if len(measures) == 0 && field != 0 {
+			decimal = sign + decimal
+		}
+		var measure string
+		measure, err = icu4c.FormatNumber(tag, "unit/"+durationUnits[unit].Name+" "+unitWidth, decimal)
+		if err != nil {
+			return
+		}
+		measures = append(measures, measure)
+	}
+	return icu4c.FormatList(tag, icu4c.ListTypeUnit, listWidth, measures)
+}
+
+// formatDigitalDuration formats fields like 1:05:00.
+// The fields after the first are padded to 2 digits.
+func formatDigitalDuration(tag language.Tag, sign string, fields []int64) (out string, err error) {
+	var digits []string
+	for i, field := range fields {
+		skeleton := "group-off"
+		if i > 0 {
+			skeleton += " integer-width/*00"
+		}
+		var s string
+		s, err = icu4c.FormatNumber(tag, skeleton, strconv.FormatInt(field, 10))
+		if err != nil {
+			return
+		}
+		digits = append(digits, s)
+	}
+	return sign + strings.Join(digits, ":"), nil
+}
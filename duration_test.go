@@ -0,0 +1,71 @@
+package messageformat
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDurationStyle(t *testing.T) {
+	test := func(style string, expected durationStyle) {
+		actual, err := parseDurationStyle(style)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%q: %v != %v\n", style, actual, expected)
+		}
+	}
+
+	test("", durationStyle{Width: "short", Largest: durationUnitDay, Smallest: durationUnitSecond})
+	test("long", durationStyle{Width: "long", Largest: durationUnitDay, Smallest: durationUnitSecond})
+	test("digital", durationStyle{Width: "digital", Largest: durationUnitHour, Smallest: durationUnitSecond})
+	test("narrow largest/hour smallest/millisecond", durationStyle{Width: "narrow", Largest: durationUnitHour, Smallest: durationUnitMillisecond})
+	test("digital largest/minute", durationStyle{Width: "digital", Largest: durationUnitMinute, Smallest: durationUnitSecond})
+	test("%with-words", durationStyle{Width: "short", Largest: durationUnitDay, Smallest: durationUnitSecond, RuleSet: "%with-words"})
+
+	testError := func(style string, expected string) {
+		_, err := parseDurationStyle(style)
+		if err == nil || err.Error() != expected {
+			t.Errorf("%q: unexpected error: %v\n", style, err)
+		}
+	}
+
+	testError("wide", "unexpected style: wide")
+	testError("largest/week", "unexpected style: largest/week")
+	testError("largest/minute smallest/hour", "largest unit minute is smaller than smallest unit hour")
+	testError("digital largest/day", "digital duration must be in hours, minutes and seconds: digital largest/day")
+	testError("digital smallest/millisecond", "digital duration must be in hours, minutes and seconds: digital smallest/millisecond")
+}
+
+func TestDurationSeconds(t *testing.T) {
+	test := func(d time.Duration, expected string) {
+		actual := durationSeconds(d)
+		if actual != expected {
+			t.Errorf("%v: %q != %q\n", d, actual, expected)
+		}
+	}
+
+	test(0, "0")
+	test(time.Hour+5*time.Minute, "3900")
+	test(1500*time.Millisecond, "1.5")
+	test(-time.Nanosecond, "-0.000000001")
+}
+
+func TestDurationFields(t *testing.T) {
+	test := func(d time.Duration, largest int, smallest int, expectedNegative bool, expectedFields []int64) {
+		negative, fields := durationFields(d, largest, smallest)
+		if negative != expectedNegative || !reflect.DeepEqual(fields, expectedFields) {
+			t.Errorf("%v: %v %v != %v %v\n", d, negative, fields, expectedNegative, expectedFields)
+		}
+	}
+
+	test(0, durationUnitDay, durationUnitSecond, false, []int64{0, 0, 0, 0})
+	test(time.Hour+5*time.Minute, durationUnitHour, durationUnitSecond, false, []int64{1, 5, 0})
+	test(26*time.Hour+30*time.Second, durationUnitDay, durationUnitSecond, false, []int64{1, 2, 0, 30})
+	test(26*time.Hour+30*time.Second, durationUnitHour, durationUnitMinute, false, []int64{26, 1})
+	test(-90*time.Second, durationUnitHour, durationUnitSecond, true, []int64{0, 1, 30})
+	test(1500*time.Millisecond, durationUnitSecond, durationUnitMillisecond, false, []int64{1, 500})
+	test(400*time.Millisecond, durationUnitMinute, durationUnitSecond, false, []int64{0, 0})
+	test(time.Duration(math.MinInt64), durationUnitDay, durationUnitDay, true, []int64{106751})
+}
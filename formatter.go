@@ -1,7 +1,10 @@
 package messageformat
 
 import (
+	"encoding"
 	"fmt"
+	"math/big"
+	"reflect"
 	"strconv"
 
 	"github.com/iawaknahc/gomessageformat/icu4c"
@@ -37,12 +40,99 @@ func formatValue(value interface{}) (out string, err error) {
 		out = v
 	case bool:
 		out = strconv.FormatBool(v)
+	case *big.Int, *big.Float, *big.Rat, Decimal, Number:
+		out, _, err = bigDecimalString(v)
+	default:
+		var b interface{}
+		b, err = builtinValue(value)
+		if err != nil {
+			return
+		}
+		out, err = formatValue(b)
+	}
+	return
+}
+
+// builtinValue converts value of a named type to the builtin type of its kind,
+// for example, `type UserID int64` to int64.
+// Otherwise, value implementing fmt.Stringer or encoding.TextMarshaler
+// is converted to string.
+// value must not be of a builtin type.
+func builtinValue(value interface{}) (out interface{}, err error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int8:
+		return int8(rv.Int()), nil
+	case reflect.Int16:
+		return int16(rv.Int()), nil
+	case reflect.Int32:
+		return int32(rv.Int()), nil
+	case reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Int:
+		return int(rv.Int()), nil
+	case reflect.Uint8:
+		return uint8(rv.Uint()), nil
+	case reflect.Uint16:
+		return uint16(rv.Uint()), nil
+	case reflect.Uint32:
+		return uint32(rv.Uint()), nil
+	case reflect.Uint64:
+		return rv.Uint(), nil
+	case reflect.Uint:
+		return uint(rv.Uint()), nil
+	case reflect.Float32:
+		return float32(rv.Float()), nil
+	case reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			err = fmt.Errorf("unsupported argument type: %T", value)
+			return
+		}
+	}
+
+	switch v := value.(type) {
+	case fmt.Stringer:
+		out = v.String()
+	case encoding.TextMarshaler:
+		var b []byte
+		b, err = v.MarshalText()
+		if err != nil {
+			return
+		}
+		out = string(b)
 	default:
 		err = fmt.Errorf("unsupported argument type: %T", value)
 	}
 	return
 }
 
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint, float32, float64:
+		return true
+	case *big.Int, *big.Float, *big.Rat, Decimal, Number:
+		return true
+	case string, bool:
+		return false
+	}
+	b, err := builtinValue(value)
+	if err != nil {
+		return false
+	}
+	switch b.(type) {
+	case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
 func offsetValue(value interface{}, offset int) (out interface{}, err error) {
 	switch v := value.(type) {
 	case int8:
@@ -69,15 +159,43 @@ func offsetValue(value interface{}, offset int) (out interface{}, err error) {
 		out = float32(float32(v) - float32(offset))
 	case float64:
 		out = float64(float64(v) - float64(offset))
-	case string:
-		var f64 float64
-		f64, err = strconv.ParseFloat(v, 64)
+	case *big.Int, *big.Float, *big.Rat:
+		// Check for nil.
+		_, _, err = bigDecimalString(v)
 		if err != nil {
 			return
 		}
-		out = strconv.FormatFloat(f64-float64(offset), 'f', -1, 64)
-	default:
+		switch v := v.(type) {
+		case *big.Int:
+			out = new(big.Int).Sub(v, big.NewInt(int64(offset)))
+		case *big.Float:
+			out = new(big.Float).Sub(v, new(big.Float).SetInt64(int64(offset)))
+		case *big.Rat:
+			out = new(big.Rat).Sub(v, new(big.Rat).SetInt64(int64(offset)))
+		}
+	case Decimal, Number:
+		var s string
+		s, _, err = bigDecimalString(v)
+		if err != nil {
+			return
+		}
+		out, err = subtractDecimal(s, offset)
+	case string:
+		out, err = subtractDecimal(v, offset)
+		if err == nil && offset == 0 {
+			// Keep the notation of v, such as the compact exponent,
+			// which is displayed as is.
+			out = v
+		}
+	case bool:
 		err = fmt.Errorf("expected numeric type: %T", value)
+	default:
+		var b interface{}
+		b, err = builtinValue(value)
+		if err != nil {
+			return
+		}
+		out, err = offsetValue(b, offset)
 	}
 	return
 }
@@ -108,15 +226,24 @@ func matchExplicitValue(value interface{}, explicitValue int) (match bool, err e
 		match = float32(v) == float32(explicitValue)
 	case float64:
 		match = float64(v) == float64(explicitValue)
-	case string:
-		var f64 float64
-		f64, err = strconv.ParseFloat(v, 64)
+	case *big.Int, *big.Float, *big.Rat, Decimal, Number:
+		var s string
+		s, _, err = bigDecimalString(v)
 		if err != nil {
 			return
 		}
-		match = f64 == float64(explicitValue)
-	default:
+		match, err = equalDecimal(s, explicitValue)
+	case string:
+		match, err = equalDecimal(v, explicitValue)
+	case bool:
 		err = fmt.Errorf("expected numeric type: %T", value)
+	default:
+		var b interface{}
+		b, err = builtinValue(value)
+		if err != nil {
+			return
+		}
+		match, err = matchExplicitValue(b, explicitValue)
 	}
 	return
 }
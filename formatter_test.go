@@ -0,0 +1,116 @@
+package messageformat
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type testUserID int64
+
+type testGender string
+
+type testStringer struct {
+	Value string
+}
+
+func (s testStringer) String() string {
+	return s.Value
+}
+
+type testTextMarshaler struct {
+	Value string
+	Err   error
+}
+
+func (m testTextMarshaler) MarshalText() ([]byte, error) {
+	return []byte(m.Value), m.Err
+}
+
+func TestFormatValue(t *testing.T) {
+	test := func(value interface{}, expected string) {
+		actual, err := formatValue(value)
+		if err != nil {
+			t.Errorf("%#v: err: %v\n", value, err)
+		} else if actual != expected {
+			t.Errorf("%#v: %q != %q\n", value, actual, expected)
+		}
+	}
+
+	test(42, "42")
+	test(1.5, "1.5")
+	test("a", "a")
+	test(true, "true")
+
+	// Named types are formatted according to their kinds.
+	test(testUserID(42), "42")
+	test(testGender("female"), "female")
+	test(time.Duration(1500), "1500")
+
+	// fmt.Stringer and encoding.TextMarshaler.
+	test(testStringer{"stringer"}, "stringer")
+	test(&testStringer{"pointer"}, "pointer")
+	test(testTextMarshaler{Value: "marshaler"}, "marshaler")
+	test(net.ParseIP("127.0.0.1"), "127.0.0.1")
+
+	testError := func(value interface{}, expected string) {
+		_, err := formatValue(value)
+		if err == nil {
+			t.Errorf("%#v: expected error\n", value)
+		} else if err.Error() != expected {
+			t.Errorf("%#v: %v != %v\n", value, err.Error(), expected)
+		}
+	}
+
+	testError(nil, "unsupported argument type: <nil>")
+	testError(struct{}{}, "unsupported argument type: struct {}")
+	testError((*testStringer)(nil), "unsupported argument type: *messageformat.testStringer")
+	testError(testTextMarshaler{Err: errors.New("marshal")}, "marshal")
+}
+
+func TestOffsetValue(t *testing.T) {
+	test := func(value interface{}, offset int, expected interface{}) {
+		actual, err := offsetValue(value, offset)
+		if err != nil {
+			t.Errorf("%#v: err: %v\n", value, err)
+		} else if actual != expected {
+			t.Errorf("%#v: %#v != %#v\n", value, actual, expected)
+		}
+	}
+
+	test(3, 1, 2)
+	test("3.5", 1, "2.5")
+	test(testUserID(3), 1, int64(2))
+	test(testGender("3"), 1, "2")
+	test(testStringer{"3"}, 1, "2")
+	test(testTextMarshaler{Value: "3"}, 1, "2")
+
+	_, err := offsetValue(true, 1)
+	if err == nil {
+		t.Errorf("expected error\n")
+	}
+}
+
+func TestMatchExplicitValue(t *testing.T) {
+	test := func(value interface{}, explicitValue int, expected bool) {
+		actual, err := matchExplicitValue(value, explicitValue)
+		if err != nil {
+			t.Errorf("%#v: err: %v\n", value, err)
+		} else if actual != expected {
+			t.Errorf("%#v: %v != %v\n", value, actual, expected)
+		}
+	}
+
+	test(1, 1, true)
+	test(2, 1, false)
+	test(testUserID(1), 1, true)
+	test(testGender("1"), 1, true)
+	test(testStringer{"1"}, 1, true)
+	test(testTextMarshaler{Value: "2"}, 1, false)
+
+	_, err := matchExplicitValue(true, 1)
+	if err == nil {
+		t.Errorf("expected error\n")
+	}
+}
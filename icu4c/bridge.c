@@ -1,22 +1,252 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 #include <string.h>
 #include <unicode/ustring.h>
 #include <unicode/udat.h>
+#include <unicode/udateintervalformat.h>
+#include <unicode/udatpg.h>
+#include <unicode/unum.h>
+#include <unicode/ulistformatter.h>
+#include <unicode/uloc.h>
+#include <unicode/unumberformatter.h>
+#include <unicode/upluralrules.h>
+#include <unicode/ureldatefmt.h>
 
 #include "bridge.h"
 
-const UErrorCode go_format_datetime(
+// initial_capacity is the capacity of the first attempt to format.
+// Longer output is formatted again with a buffer of the preflighted length.
+#define initial_capacity 64
+
+// pattern_capacity is the capacity of date format patterns and skeletons.
+#define pattern_capacity 256
+
+// go_to_utf8 converts src to a null-terminated UTF-8 string allocated with malloc.
+// The caller must free *result.
+static UErrorCode go_to_utf8(
+	const UChar* src,
+	int32_t src_length,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	int32_t length = 0;
+
+	// Preflight the length.
+	u_strToUTF8(NULL, 0, &length, src, src_length, &status);
+	if (status != U_BUFFER_OVERFLOW_ERROR && U_FAILURE(status)) {
+		return status;
+	}
+	status = U_ZERO_ERROR;
+
+	char* buf = malloc(length + 1);
+	if (buf == NULL) {
+		return U_MEMORY_ALLOCATION_ERROR;
+	}
+	u_strToUTF8(buf, length + 1, NULL, src, src_length, &status);
+	if (U_FAILURE(status)) {
+		free(buf);
+		return status;
+	}
+
+	*result = buf;
+	*result_length = length;
+	return U_ZERO_ERROR;
+}
+
+// go_from_utf8 converts the UTF-8 string src to a UTF-16 string allocated with malloc.
+// The caller must free *result.
+static UErrorCode go_from_utf8(
+	const char* src,
+	int32_t src_length,
+	UChar** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	int32_t length = 0;
+
+	// Preflight the length.
+	u_strFromUTF8(NULL, 0, &length, src, src_length, &status);
+	if (status != U_BUFFER_OVERFLOW_ERROR && U_FAILURE(status)) {
+		return status;
+	}
+	status = U_ZERO_ERROR;
+
+	UChar* buf = malloc((length + 1) * sizeof(UChar));
+	if (buf == NULL) {
+		return U_MEMORY_ALLOCATION_ERROR;
+	}
+	u_strFromUTF8(buf, length + 1, NULL, src, src_length, &status);
+	if (U_FAILURE(status)) {
+		free(buf);
+		return status;
+	}
+
+	*result = buf;
+	*result_length = length;
+	return U_ZERO_ERROR;
+}
+
+// go_locale_for_language_tag converts the BCP 47 language tag to an ICU locale ID,
+// so that extensions like -u-ca-japanese become keywords like @calendar=japanese.
+static UErrorCode go_locale_for_language_tag(
+	const char* language_tag,
+	char* locale,
+	int32_t capacity
+) {
+	UErrorCode status = U_ZERO_ERROR;
+
+	uloc_forLanguageTag(language_tag, locale, capacity, NULL, &status);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+	if (status == U_STRING_NOT_TERMINATED_WARNING) {
+		return U_BUFFER_OVERFLOW_ERROR;
+	}
+	return U_ZERO_ERROR;
+}
+
+// go_hour_field returns the pattern letter of the hour cycle hours,
+// or 0 if hours is unknown.
+static UChar go_hour_field(const char* hours) {
+	if (strcmp(hours, "h11") == 0) {
+		return u'K';
+	}
+	if (strcmp(hours, "h12") == 0) {
+		return u'h';
+	}
+	if (strcmp(hours, "h23") == 0) {
+		return u'H';
+	}
+	if (strcmp(hours, "h24") == 0) {
+		return u'k';
+	}
+	return 0;
+}
+
+// go_apply_hour_cycle applies the hours keyword of locale, such as
+// @hours=h23 from -u-hc-h23, which udat_open ignores.
+// The time pattern of time_style is regenerated from its skeleton
+// with the hour field of the hour cycle, and then replaced in the pattern of fmt.
+static UErrorCode go_apply_hour_cycle(
+	UDateFormat* fmt,
 	const char* locale,
+	const UChar* tz,
+	UDateFormatStyle time_style
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	char hours[ULOC_KEYWORDS_CAPACITY];
+	UChar pattern[pattern_capacity];
+	UChar time_pattern[pattern_capacity];
+	UChar skeleton[pattern_capacity];
+	UChar hour_skeleton[pattern_capacity];
+	UChar hour_pattern[pattern_capacity];
+	UChar result[pattern_capacity * 2];
+	int32_t pattern_length = 0;
+	int32_t time_pattern_length = 0;
+	int32_t skeleton_length = 0;
+	int32_t hour_skeleton_length = 0;
+	int32_t hour_pattern_length = 0;
+	UDateFormat* time_fmt = NULL;
+	UDateTimePatternGenerator* dtpg = NULL;
+
+	if (time_style == UDAT_NONE) {
+		return U_ZERO_ERROR;
+	}
+	int32_t hours_length = uloc_getKeywordValue(locale, "hours", hours, ULOC_KEYWORDS_CAPACITY, &status);
+	if (U_FAILURE(status) || hours_length == 0) {
+		return status;
+	}
+	UChar hour = go_hour_field(hours);
+	if (hour == 0) {
+		return U_ZERO_ERROR;
+	}
+	bool is_24_hour = hour == u'H' || hour == u'k';
+
+	pattern_length = udat_toPattern(fmt, false, pattern, pattern_capacity, &status);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	time_fmt = udat_open(time_style, UDAT_NONE, locale, tz, -1, NULL, -1, &status);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+	time_pattern_length = udat_toPattern(time_fmt, false, time_pattern, pattern_capacity, &status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+
+	dtpg = udatpg_open(locale, &status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+	skeleton_length = udatpg_getSkeleton(dtpg, time_pattern, time_pattern_length, skeleton, pattern_capacity, &status);
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	for (int32_t i = 0; i < skeleton_length; i++) {
+		UChar c = skeleton[i];
+		if (c == u'h' || c == u'H' || c == u'k' || c == u'K' || c == u'j' || c == u'J' || c == u'C') {
+			hour_skeleton[hour_skeleton_length++] = hour;
+		} else if (is_24_hour && (c == u'a' || c == u'b' || c == u'B')) {
+			// A 24-hour clock has no day period.
+		} else {
+			hour_skeleton[hour_skeleton_length++] = c;
+		}
+	}
+
+	hour_pattern_length = udatpg_getBestPatternWithOptions(
+		dtpg,
+		hour_skeleton,
+		hour_skeleton_length,
+		UDATPG_MATCH_NO_OPTIONS,
+		hour_pattern,
+		pattern_capacity,
+		&status
+	);
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	// The time pattern appears verbatim in the pattern of a date and time style.
+	UChar* found = u_strFindFirst(pattern, pattern_length, time_pattern, time_pattern_length);
+	if (found != NULL) {
+		int32_t prefix_length = found - pattern;
+		int32_t suffix_length = pattern_length - prefix_length - time_pattern_length;
+		u_memcpy(result, pattern, prefix_length);
+		u_memcpy(result + prefix_length, hour_pattern, hour_pattern_length);
+		u_memcpy(result + prefix_length + hour_pattern_length, found + time_pattern_length, suffix_length);
+		udat_applyPattern(fmt, false, result, prefix_length + hour_pattern_length + suffix_length);
+	}
+
+	status = U_ZERO_ERROR;
+exit2:
+	udatpg_close(dtpg);
+exit1:
+	udat_close(time_fmt);
+exit0:
+	return status;
+}
+
+const UErrorCode go_open_datetime_format(
+	const char* language_tag,
 	const char* tz,
 	UDateFormatStyle date_style,
 	UDateFormatStyle time_style,
-	double msec,
-	char* const result,
-	const size_t result_size
+	UDateFormat** result
 ) {
 	UErrorCode status = U_ZERO_ERROR;
-	UChar buf[result_size];
 
-	UChar tzUchar[strlen(tz) * sizeof(UChar)];
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	UChar tzUchar[strlen(tz) + 1];
 	u_uastrcpy(tzUchar, tz);
 
 	UDateFormat* fmt = udat_open(
@@ -30,24 +260,629 @@ const UErrorCode go_format_datetime(
 		&status
 	);
 	if (U_FAILURE(status)) {
-		goto exit0;
+		return status;
 	}
 
-	udat_format(
+	status = go_apply_hour_cycle(fmt, locale, tzUchar, time_style);
+	if (U_FAILURE(status)) {
+		udat_close(fmt);
+		return status;
+	}
+
+	*result = fmt;
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	return U_ZERO_ERROR;
+}
+
+const UErrorCode go_format_datetime(
+	const UDateFormat* fmt,
+	double msec,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar stack_buf[initial_capacity];
+	UChar* buf = stack_buf;
+	int32_t length = 0;
+
+	length = udat_format(
 		fmt,
 		(UDate)msec,
 		buf,
-		result_size,
+		initial_capacity,
 		NULL,
 		&status
 	);
+	if (status == U_BUFFER_OVERFLOW_ERROR) {
+		status = U_ZERO_ERROR;
+		buf = malloc((length + 1) * sizeof(UChar));
+		if (buf == NULL) {
+			return U_MEMORY_ALLOCATION_ERROR;
+		}
+		length = udat_format(
+			fmt,
+			(UDate)msec,
+			buf,
+			length + 1,
+			NULL,
+			&status
+		);
+	}
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	status = go_to_utf8(buf, length, result, result_length);
+exit0:
+	if (buf != stack_buf) {
+		free(buf);
+	}
+	return status;
+}
+
+const UErrorCode go_plural_select(
+	const char* language_tag,
+	UPluralType type,
+	double number,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar buf[initial_capacity];
+	int32_t length = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	UPluralRules* rules = uplrules_openForType(locale, type, &status);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	length = uplrules_select(rules, number, buf, initial_capacity, &status);
 	if (U_FAILURE(status)) {
 		goto exit1;
 	}
 
-	u_austrcpy(result, buf);
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit1:
+	uplrules_close(rules);
+exit0:
+	return status;
+}
+
+const UErrorCode go_open_plural_formatter(
+	const char* language_tag,
+	UPluralType type,
+	const char* skeleton,
+	GoPluralFormatter** result
+) {
+	UErrorCode status = U_ZERO_ERROR;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	UChar skeletonUchar[strlen(skeleton) + 1];
+	u_uastrcpy(skeletonUchar, skeleton);
+
+	GoPluralFormatter* formatter = malloc(sizeof(GoPluralFormatter));
+	if (formatter == NULL) {
+		return U_MEMORY_ALLOCATION_ERROR;
+	}
+
+	formatter->fmt = unumf_openForSkeletonAndLocale(
+		skeletonUchar,
+		-1, // -1 because skeletonUchar is null-terminated.
+		locale,
+		&status
+	);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	formatter->rules = uplrules_openForType(locale, type, &status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+
+	*result = formatter;
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	return U_ZERO_ERROR;
+exit1:
+	unumf_close(formatter->fmt);
+exit0:
+	free(formatter);
+	return status;
+}
+
+void go_close_plural_formatter(GoPluralFormatter* formatter) {
+	uplrules_close(formatter->rules);
+	unumf_close(formatter->fmt);
+	free(formatter);
+}
+
+const UErrorCode go_plural_select_formatted(
+	const GoPluralFormatter* formatter,
+	const char* decimal,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar buf[initial_capacity];
+	int32_t length = 0;
+
+	UFormattedNumber* formatted = unumf_openResult(&status);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	unumf_formatDecimal(formatter->fmt, decimal, -1, formatted, &status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+
+	length = uplrules_selectFormatted(formatter->rules, formatted, buf, initial_capacity, &status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit1:
+	unumf_closeResult(formatted);
+exit0:
+	return status;
+}
+
+const UErrorCode go_plural_keywords(
+	const char* language_tag,
+	UPluralType type,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	int32_t length = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	UPluralRules* rules = uplrules_openForType(locale, type, &status);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	UEnumeration* keywords = uplrules_getKeywords(rules, &status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+
+	// The keywords are ASCII, so they are joined with spaces as is.
+	// There are at most 6 keywords of at most 5 bytes.
+	char* buf = malloc(initial_capacity);
+	if (buf == NULL) {
+		status = U_MEMORY_ALLOCATION_ERROR;
+		goto exit2;
+	}
+	const char* keyword = NULL;
+	int32_t keyword_length = 0;
+	while ((keyword = uenum_next(keywords, &keyword_length, &status)) != NULL) {
+		if (length + keyword_length + 1 >= initial_capacity) {
+			status = U_BUFFER_OVERFLOW_ERROR;
+			break;
+		}
+		if (length > 0) {
+			buf[length++] = ' ';
+		}
+		memcpy(buf + length, keyword, keyword_length);
+		length += keyword_length;
+	}
+	if (U_FAILURE(status)) {
+		free(buf);
+		goto exit2;
+	}
+	buf[length] = '\0';
+
+	*result = buf;
+	*result_length = length;
+exit2:
+	uenum_close(keywords);
+exit1:
+	uplrules_close(rules);
+exit0:
+	return status;
+}
+
+const UErrorCode go_format_number(
+	const char* language_tag,
+	const char* skeleton,
+	const char* decimal,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar stack_buf[initial_capacity];
+	UChar* buf = stack_buf;
+	int32_t length = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	UChar skeletonUchar[strlen(skeleton) + 1];
+	u_uastrcpy(skeletonUchar, skeleton);
+
+	UNumberFormatter* fmt = unumf_openForSkeletonAndLocale(
+		skeletonUchar,
+		-1, // -1 because skeletonUchar is null-terminated.
+		locale,
+		&status
+	);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	UFormattedNumber* formatted = unumf_openResult(&status);
+	if (U_FAILURE(status)) {
+		goto exit1;
+	}
+
+	unumf_formatDecimal(fmt, decimal, -1, formatted, &status);
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	length = unumf_resultToString(formatted, buf, initial_capacity, &status);
+	if (status == U_BUFFER_OVERFLOW_ERROR) {
+		status = U_ZERO_ERROR;
+		buf = malloc((length + 1) * sizeof(UChar));
+		if (buf == NULL) {
+			status = U_MEMORY_ALLOCATION_ERROR;
+			goto exit2;
+		}
+		length = unumf_resultToString(formatted, buf, length + 1, &status);
+	}
+	if (U_FAILURE(status)) {
+		goto exit3;
+	}
+
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit3:
+	if (buf != stack_buf) {
+		free(buf);
+	}
+exit2:
+	unumf_closeResult(formatted);
+exit1:
+	unumf_close(fmt);
+exit0:
+	return status;
+}
+
+const UErrorCode go_format_date_interval(
+	const char* language_tag,
+	const char* tz,
+	const char* skeleton,
+	double from_msec,
+	double to_msec,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar stack_buf[initial_capacity];
+	UChar* buf = stack_buf;
+	int32_t length = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	UChar tzUchar[strlen(tz) + 1];
+	u_uastrcpy(tzUchar, tz);
+
+	UChar skeletonUchar[strlen(skeleton) + 1];
+	u_uastrcpy(skeletonUchar, skeleton);
+
+	UDateIntervalFormat* fmt = udtitvfmt_open(
+		locale,
+		skeletonUchar,
+		-1, // -1 because skeletonUchar is null-terminated.
+		tzUchar,
+		-1, // -1 because tzUchar is null-terminated.
+		&status
+	);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	length = udtitvfmt_format(
+		fmt,
+		(UDate)from_msec,
+		(UDate)to_msec,
+		buf,
+		initial_capacity,
+		NULL,
+		&status
+	);
+	if (status == U_BUFFER_OVERFLOW_ERROR) {
+		status = U_ZERO_ERROR;
+		buf = malloc((length + 1) * sizeof(UChar));
+		if (buf == NULL) {
+			status = U_MEMORY_ALLOCATION_ERROR;
+			goto exit1;
+		}
+		length = udtitvfmt_format(
+			fmt,
+			(UDate)from_msec,
+			(UDate)to_msec,
+			buf,
+			length + 1,
+			NULL,
+			&status
+		);
+	}
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit2:
+	if (buf != stack_buf) {
+		free(buf);
+	}
+exit1:
+	udtitvfmt_close(fmt);
+exit0:
+	return status;
+}
+
+const UErrorCode go_format_relative_time(
+	const char* language_tag,
+	UDateRelativeDateTimeFormatterStyle style,
+	bool numeric,
+	URelativeDateTimeUnit unit,
+	double offset,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar stack_buf[initial_capacity];
+	UChar* buf = stack_buf;
+	int32_t length = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	URelativeDateTimeFormatter* fmt = ureldatefmt_open(
+		locale,
+		NULL, // NULL to use the default number format of locale.
+		style,
+		UDISPCTX_CAPITALIZATION_NONE,
+		&status
+	);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	// ureldatefmt_format uses phrases such as "yesterday" when available,
+	// while ureldatefmt_formatNumeric always formats the offset as a number.
+	if (numeric) {
+		length = ureldatefmt_formatNumeric(fmt, offset, unit, buf, initial_capacity, &status);
+	} else {
+		length = ureldatefmt_format(fmt, offset, unit, buf, initial_capacity, &status);
+	}
+	if (status == U_BUFFER_OVERFLOW_ERROR) {
+		status = U_ZERO_ERROR;
+		buf = malloc((length + 1) * sizeof(UChar));
+		if (buf == NULL) {
+			status = U_MEMORY_ALLOCATION_ERROR;
+			goto exit1;
+		}
+		if (numeric) {
+			length = ureldatefmt_formatNumeric(fmt, offset, unit, buf, length + 1, &status);
+		} else {
+			length = ureldatefmt_format(fmt, offset, unit, buf, length + 1, &status);
+		}
+	}
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit2:
+	if (buf != stack_buf) {
+		free(buf);
+	}
+exit1:
+	ureldatefmt_close(fmt);
+exit0:
+	return status;
+}
+
+const UErrorCode go_format_list(
+	const char* language_tag,
+	UListFormatterType type,
+	UListFormatterWidth width,
+	const char* const* items,
+	const int32_t* item_lengths,
+	int32_t item_count,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar stack_buf[initial_capacity];
+	UChar* buf = stack_buf;
+	int32_t length = 0;
+	int32_t converted = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	// calloc instead of a VLA because item_count can be 0.
+	UChar** strings = calloc(item_count + 1, sizeof(UChar*));
+	int32_t* string_lengths = calloc(item_count + 1, sizeof(int32_t));
+	if (strings == NULL || string_lengths == NULL) {
+		status = U_MEMORY_ALLOCATION_ERROR;
+		goto exit0;
+	}
+	for (; converted < item_count; converted++) {
+		status = go_from_utf8(
+			items[converted],
+			item_lengths[converted],
+			&strings[converted],
+			&string_lengths[converted]
+		);
+		if (U_FAILURE(status)) {
+			goto exit0;
+		}
+	}
+
+	UListFormatter* fmt = ulistfmt_openForType(locale, type, width, &status);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	length = ulistfmt_format(
+		fmt,
+		(const UChar* const*)strings,
+		string_lengths,
+		item_count,
+		buf,
+		initial_capacity,
+		&status
+	);
+	if (status == U_BUFFER_OVERFLOW_ERROR) {
+		status = U_ZERO_ERROR;
+		buf = malloc((length + 1) * sizeof(UChar));
+		if (buf == NULL) {
+			status = U_MEMORY_ALLOCATION_ERROR;
+			goto exit1;
+		}
+		length = ulistfmt_format(
+			fmt,
+			(const UChar* const*)strings,
+			string_lengths,
+			item_count,
+			buf,
+			length + 1,
+			&status
+		);
+	}
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit2:
+	if (buf != stack_buf) {
+		free(buf);
+	}
+exit1:
+	ulistfmt_close(fmt);
+exit0:
+	if (strings != NULL) {
+		for (int32_t i = 0; i < converted; i++) {
+			free(strings[i]);
+		}
+	}
+	free(strings);
+	free(string_lengths);
+	return status;
+}
+
+const UErrorCode go_format_rule_based_number(
+	const char* language_tag,
+	UNumberFormatStyle style,
+	const char* rule_set,
+	const char* decimal,
+	char** result,
+	int32_t* result_length
+) {
+	UErrorCode status = U_ZERO_ERROR;
+	UChar stack_buf[initial_capacity];
+	UChar* buf = stack_buf;
+	int32_t length = 0;
+
+	char locale[ULOC_FULLNAME_CAPACITY];
+	status = go_locale_for_language_tag(language_tag, locale, ULOC_FULLNAME_CAPACITY);
+	if (U_FAILURE(status)) {
+		return status;
+	}
+
+	UChar ruleSetUchar[strlen(rule_set) + 1];
+	u_uastrcpy(ruleSetUchar, rule_set);
+
+	UNumberFormat* fmt = unum_open(
+		style,
+		NULL, // pattern is unused here.
+		0, // pattern is unused here.
+		locale,
+		NULL, // parseErr is unused here.
+		&status
+	);
+	if (U_FAILURE(status)) {
+		goto exit0;
+	}
+
+	// An empty rule set is the default rule set of the style.
+	if (ruleSetUchar[0] != 0) {
+		unum_setTextAttribute(
+			fmt,
+			UNUM_DEFAULT_RULESET,
+			ruleSetUchar,
+			-1, // -1 because ruleSetUchar is null-terminated.
+			&status
+		);
+		if (U_FAILURE(status)) {
+			goto exit1;
+		}
+	}
+
+	length = unum_formatDecimal(fmt, decimal, -1, buf, initial_capacity, NULL, &status);
+	if (status == U_BUFFER_OVERFLOW_ERROR) {
+		status = U_ZERO_ERROR;
+		buf = malloc((length + 1) * sizeof(UChar));
+		if (buf == NULL) {
+			status = U_MEMORY_ALLOCATION_ERROR;
+			goto exit1;
+		}
+		length = unum_formatDecimal(fmt, decimal, -1, buf, length + 1, NULL, &status);
+	}
+	if (U_FAILURE(status)) {
+		goto exit2;
+	}
+
+	// Warnings such as U_USING_DEFAULT_WARNING are not errors.
+	status = go_to_utf8(buf, length, result, result_length);
+exit2:
+	if (buf != stack_buf) {
+		free(buf);
+	}
 exit1:
-	udat_close(fmt);
+	unum_close(fmt);
 exit0:
 	return status;
 }
@@ -1,3 +1,6 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 package icu4c
 
 // #cgo pkg-config: icu-i18n icu-uc
@@ -5,22 +8,14 @@ package icu4c
 import "C"
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 	"time"
 	"unsafe"
 
 	"golang.org/x/text/language"
 )
 
-const bufferSize = 512
-
-var ErrLocalTZ = errors.New("icu4c: Go Local tz name is not supported")
-
-// TZName is the tz database name such as "Asia/Hong_Kong".
-// Note that this is different from the name returned by time.Zone().
-type TZName string
-
 type DateFormatStyle C.UDateFormatStyle
 
 const (
@@ -31,7 +26,123 @@ const (
 	DateFormatStyleFull   = C.UDAT_FULL
 )
 
+// FormatDatetime formats t with the handles cached in DefaultDateFormatCache.
 func FormatDatetime(languageTag language.Tag, tzName TZName, dateStyle DateFormatStyle, timeStyle DateFormatStyle, t time.Time) (out string, err error) {
+	return DefaultDateFormatCache.FormatDatetime(languageTag, tzName, dateStyle, timeStyle, t)
+}
+
+type PluralType C.UPluralType
+
+const (
+	PluralTypeCardinal = C.UPLURAL_TYPE_CARDINAL
+	PluralTypeOrdinal  = C.UPLURAL_TYPE_ORDINAL
+)
+
+// PluralSelect selects the plural keyword of number with uplrules_select.
+// Since number is a float64, the visible fraction digits are not considered.
+func PluralSelect(languageTag language.Tag, pluralType PluralType, number float64) (out string, err error) {
+	locale := languageTag.String()
+	cLocale := C.CString(locale)
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_plural_select(
+		cLocale,
+		C.UPluralType(pluralType),
+		C.double(number),
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+// PluralSelectFormatted selects the plural keyword of decimal formatted with
+// the number skeleton with the handles cached in DefaultPluralRulesCache.
+// For example, with the skeleton ".0", "1" is formatted as "1.0" in English,
+// and its keyword is other.
+// See https://unicode-org.github.io/icu/userguide/format_parse/numbers/skeletons.html
+func PluralSelectFormatted(languageTag language.Tag, pluralType PluralType, skeleton string, decimal string) (out string, err error) {
+	return DefaultPluralRulesCache.PluralSelectFormatted(languageTag, pluralType, skeleton, decimal)
+}
+
+// PluralKeywords returns the plural keywords of the rules of pluralType
+// with uplrules_getKeywords, in the order of icu4c.
+func PluralKeywords(languageTag language.Tag, pluralType PluralType) (out []string, err error) {
+	locale := languageTag.String()
+	cLocale := C.CString(locale)
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_plural_keywords(
+		cLocale,
+		C.UPluralType(pluralType),
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = strings.Fields(C.GoStringN(result, C.int(resultLength)))
+	return
+}
+
+// FormatNumber formats decimal with the number skeleton.
+// decimal is a decimal string like "-1234.5".
+// See https://unicode-org.github.io/icu/userguide/format_parse/numbers/skeletons.html
+func FormatNumber(languageTag language.Tag, skeleton string, decimal string) (out string, err error) {
+	locale := languageTag.String()
+	cLocale := C.CString(locale)
+	cSkeleton := C.CString(skeleton)
+	cDecimal := C.CString(decimal)
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(cSkeleton))
+		C.free(unsafe.Pointer(cDecimal))
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_format_number(
+		cLocale,
+		cSkeleton,
+		cDecimal,
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+// FormatDateInterval formats the interval from start to end with the date skeleton
+// with udtitvfmt_format.
+// The fields that start and end share are formatted once, such as "Jan 3 – 5, 2025".
+// See https://unicode-org.github.io/icu/userguide/format_parse/datetime/#date-interval-formatting
+func FormatDateInterval(languageTag language.Tag, tzName TZName, skeleton string, start time.Time, end time.Time) (out string, err error) {
 	if tzName == "Local" {
 		err = ErrLocalTZ
 		return
@@ -40,30 +151,191 @@ func FormatDatetime(languageTag language.Tag, tzName TZName, dateStyle DateForma
 	locale := languageTag.String()
 	cLocale := C.CString(locale)
 	cTZ := C.CString(string(tzName))
-	msec := C.double(t.UnixNano() / int64(time.Millisecond))
-	resultSize := C.size_t(bufferSize * C.sizeof_char)
-	result := (*C.char)(C.malloc(resultSize))
+	cSkeleton := C.CString(skeleton)
+	var result *C.char
+	var resultLength C.int32_t
 
 	defer func() {
 		C.free(unsafe.Pointer(cLocale))
 		C.free(unsafe.Pointer(cTZ))
+		C.free(unsafe.Pointer(cSkeleton))
 		C.free(unsafe.Pointer(result))
 	}()
 
-	status := C.go_format_datetime(
+	status := C.go_format_date_interval(
 		cLocale,
 		cTZ,
-		C.UDateFormatStyle(dateStyle),
-		C.UDateFormatStyle(timeStyle),
-		msec,
-		result,
-		resultSize,
+		cSkeleton,
+		C.double(start.UnixNano()/int64(time.Millisecond)),
+		C.double(end.UnixNano()/int64(time.Millisecond)),
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+type RelativeTimeStyle C.UDateRelativeDateTimeFormatterStyle
+
+const (
+	RelativeTimeStyleLong   = C.UDAT_STYLE_LONG
+	RelativeTimeStyleShort  = C.UDAT_STYLE_SHORT
+	RelativeTimeStyleNarrow = C.UDAT_STYLE_NARROW
+)
+
+type RelativeTimeUnit C.URelativeDateTimeUnit
+
+const (
+	RelativeTimeUnitYear    = C.UDAT_REL_UNIT_YEAR
+	RelativeTimeUnitQuarter = C.UDAT_REL_UNIT_QUARTER
+	RelativeTimeUnitMonth   = C.UDAT_REL_UNIT_MONTH
+	RelativeTimeUnitWeek    = C.UDAT_REL_UNIT_WEEK
+	RelativeTimeUnitDay     = C.UDAT_REL_UNIT_DAY
+	RelativeTimeUnitHour    = C.UDAT_REL_UNIT_HOUR
+	RelativeTimeUnitMinute  = C.UDAT_REL_UNIT_MINUTE
+	RelativeTimeUnitSecond  = C.UDAT_REL_UNIT_SECOND
+)
+
+// FormatRelativeTime formats offset units relative to now, such as "in 2 hours" or "3 days ago".
+// A negative offset is in the past.
+// If numeric is false, phrases such as "yesterday" are used when available.
+func FormatRelativeTime(languageTag language.Tag, style RelativeTimeStyle, numeric bool, unit RelativeTimeUnit, offset float64) (out string, err error) {
+	locale := languageTag.String()
+	cLocale := C.CString(locale)
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_format_relative_time(
+		cLocale,
+		C.UDateRelativeDateTimeFormatterStyle(style),
+		C.bool(numeric),
+		C.URelativeDateTimeUnit(unit),
+		C.double(offset),
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+type ListType C.UListFormatterType
+
+const (
+	ListTypeConjunction = C.ULISTFMT_TYPE_AND
+	ListTypeDisjunction = C.ULISTFMT_TYPE_OR
+	ListTypeUnit        = C.ULISTFMT_TYPE_UNITS
+)
+
+type ListWidth C.UListFormatterWidth
+
+const (
+	ListWidthWide   = C.ULISTFMT_WIDTH_WIDE
+	ListWidthShort  = C.ULISTFMT_WIDTH_SHORT
+	ListWidthNarrow = C.ULISTFMT_WIDTH_NARROW
+)
+
+// FormatList joins items with ulistfmt_format, such as "A, B, and C".
+func FormatList(languageTag language.Tag, listType ListType, width ListWidth, items []string) (out string, err error) {
+	locale := languageTag.String()
+	cLocale := C.CString(locale)
+	var result *C.char
+	var resultLength C.int32_t
+
+	// The arrays are allocated in C because C must not keep pointers to Go pointers.
+	n := len(items)
+	cItemsPtr := C.malloc(C.size_t(n+1) * C.size_t(unsafe.Sizeof((*C.char)(nil))))
+	cItemLengthsPtr := C.malloc(C.size_t(n+1) * C.size_t(unsafe.Sizeof(C.int32_t(0))))
+	cItems := (*[1 << 28]*C.char)(cItemsPtr)[:n:n]
+	cItemLengths := (*[1 << 28]C.int32_t)(cItemLengthsPtr)[:n:n]
+	for i, item := range items {
+		cItems[i] = C.CString(item)
+		cItemLengths[i] = C.int32_t(len(item))
+	}
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		for _, cItem := range cItems {
+			C.free(unsafe.Pointer(cItem))
+		}
+		C.free(cItemsPtr)
+		C.free(cItemLengthsPtr)
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_format_list(
+		cLocale,
+		C.UListFormatterType(listType),
+		C.UListFormatterWidth(width),
+		(**C.char)(cItemsPtr),
+		(*C.int32_t)(cItemLengthsPtr),
+		C.int32_t(n),
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+type RuleBasedNumberStyle C.UNumberFormatStyle
+
+const (
+	RuleBasedNumberStyleSpellout = C.UNUM_SPELLOUT
+	RuleBasedNumberStyleOrdinal  = C.UNUM_ORDINAL
+	RuleBasedNumberStyleDuration = C.UNUM_DURATION
+)
+
+// FormatRuleBasedNumber formats decimal with RuleBasedNumberFormat,
+// such as "twenty-one" and "21st".
+// ruleSet is a rule set of the locale, such as "%spellout-ordinal".
+// If it is empty, the default rule set of style is used.
+func FormatRuleBasedNumber(languageTag language.Tag, style RuleBasedNumberStyle, ruleSet string, decimal string) (out string, err error) {
+	locale := languageTag.String()
+	cLocale := C.CString(locale)
+	cRuleSet := C.CString(ruleSet)
+	cDecimal := C.CString(decimal)
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(cRuleSet))
+		C.free(unsafe.Pointer(cDecimal))
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_format_rule_based_number(
+		cLocale,
+		C.UNumberFormatStyle(style),
+		cRuleSet,
+		cDecimal,
+		&result,
+		&resultLength,
 	)
 	if status != 0 {
 		err = fmt.Errorf("icu4c: %v", status)
 		return
 	}
 
-	out = C.GoString(result)
+	out = C.GoStringN(result, C.int(resultLength))
 	return
 }
@@ -5,15 +5,109 @@
 #include <stdbool.h>
 #include <unicode/utypes.h>
 #include <unicode/udat.h>
+#include <unicode/ulistformatter.h>
+#include <unicode/unum.h>
+#include <unicode/unumberformatter.h>
+#include <unicode/upluralrules.h>
+#include <unicode/ureldatefmt.h>
 
-const UErrorCode go_format_datetime(
-	const char* locale,
+const UErrorCode go_open_datetime_format(
+	const char* language_tag,
 	const char* tz,
 	UDateFormatStyle date_style,
 	UDateFormatStyle time_style,
+	UDateFormat** result
+);
+
+const UErrorCode go_format_datetime(
+	const UDateFormat* fmt,
 	double msec,
-	char* const result,
-	const size_t result_size
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_plural_select(
+	const char* language_tag,
+	UPluralType type,
+	double number,
+	char** result,
+	int32_t* result_length
+);
+
+typedef struct {
+	UNumberFormatter* fmt;
+	UPluralRules* rules;
+} GoPluralFormatter;
+
+const UErrorCode go_open_plural_formatter(
+	const char* language_tag,
+	UPluralType type,
+	const char* skeleton,
+	GoPluralFormatter** result
+);
+
+void go_close_plural_formatter(GoPluralFormatter* formatter);
+
+const UErrorCode go_plural_select_formatted(
+	const GoPluralFormatter* formatter,
+	const char* decimal,
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_plural_keywords(
+	const char* language_tag,
+	UPluralType type,
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_format_number(
+	const char* language_tag,
+	const char* skeleton,
+	const char* decimal,
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_format_date_interval(
+	const char* language_tag,
+	const char* tz,
+	const char* skeleton,
+	double from_msec,
+	double to_msec,
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_format_relative_time(
+	const char* language_tag,
+	UDateRelativeDateTimeFormatterStyle style,
+	bool numeric,
+	URelativeDateTimeUnit unit,
+	double offset,
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_format_list(
+	const char* language_tag,
+	UListFormatterType type,
+	UListFormatterWidth width,
+	const char* const* items,
+	const int32_t* item_lengths,
+	int32_t item_count,
+	char** result,
+	int32_t* result_length
+);
+
+const UErrorCode go_format_rule_based_number(
+	const char* language_tag,
+	UNumberFormatStyle style,
+	const char* rule_set,
+	const char* decimal,
+	char** result,
+	int32_t* result_length
 );
 
 #endif //__C_BRIDGE_H__
@@ -1,6 +1,12 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 package icu4c
 
 import (
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -64,3 +70,284 @@ func TestFormatDatetimeLocal(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestPluralSelect(t *testing.T) {
+	test := func(lang string, pluralType PluralType, number float64, expected string) {
+		actual, err := PluralSelect(language.Make(lang), pluralType, number)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %v != %v", lang, number, actual, expected)
+		}
+	}
+
+	test("en", PluralTypeCardinal, 1, "one")
+	test("en", PluralTypeCardinal, 2, "other")
+	test("en", PluralTypeOrdinal, 2, "two")
+	test("ru", PluralTypeCardinal, 22, "few")
+	test("mt", PluralTypeCardinal, 2, "two")
+}
+
+func TestPluralSelectFormatted(t *testing.T) {
+	test := func(lang string, pluralType PluralType, skeleton string, decimal string, expected string) {
+		actual, err := PluralSelectFormatted(language.Make(lang), pluralType, skeleton, decimal)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v %v: %v != %v", lang, skeleton, decimal, actual, expected)
+		}
+	}
+
+	test("en", PluralTypeCardinal, "precision-integer", "1", "one")
+	test("en", PluralTypeCardinal, ".0", "1", "other")
+	test("en", PluralTypeCardinal, ".00", "1.5", "other")
+	test("en", PluralTypeOrdinal, "precision-integer", "23", "few")
+	test("fr", PluralTypeCardinal, "compact-short precision-unlimited", "1200000", "many")
+	test("he", PluralTypeCardinal, ".0", "0.5", "one")
+}
+
+func TestPluralKeywords(t *testing.T) {
+	test := func(lang string, pluralType PluralType, expected []string) {
+		actual, err := PluralKeywords(language.Make(lang), pluralType)
+		if err != nil {
+			t.Errorf("err: %v", err)
+			return
+		}
+		sort.Strings(actual)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%v %v: %v != %v", lang, pluralType, actual, expected)
+		}
+	}
+
+	test("en", PluralTypeCardinal, []string{"one", "other"})
+	test("en", PluralTypeOrdinal, []string{"few", "one", "other", "two"})
+	test("fr", PluralTypeCardinal, []string{"many", "one", "other"})
+	test("zh", PluralTypeCardinal, []string{"other"})
+}
+
+func TestPluralSelectFormattedError(t *testing.T) {
+	_, err := PluralSelectFormatted(language.English, PluralTypeCardinal, "nonsense", "1")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestFormatDatetimeLongOutput(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	tz := TZName("America/Argentina/ComodRivadavia")
+
+	test := func(lang string, expected string) {
+		actual, err := FormatDatetime(language.Make(lang), tz, DateFormatStyleFull, DateFormatStyleFull, now)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v != %v", actual, expected)
+		}
+	}
+
+	// U+202F NARROW NO-BREAK SPACE is written as an escape because it is invisible.
+	test("en", "Tuesday, November 10, 2009 at 8:00:00\u202fPM Argentina Standard Time")
+	test("am", "2009 ኖቬምበር 10, ማክሰኞ 8:00:00 ከሰዓት የአርጀንቲና መደበኛ ሰዓት አቆጣጠር")
+	test("ta", "செவ்வாய், 10 நவம்பர், 2009 அன்று பிற்பகல் 8:00:00 அர்ஜென்டினா நிலையான நேரம்")
+}
+
+func TestFormatDatetimeCalendar(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	tz := TZName("UTC")
+
+	test := func(lang string, expected string) {
+		actual, err := FormatDatetime(language.Make(lang), tz, DateFormatStyleLong, DateFormatStyleNone, now)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v: %v != %v", lang, actual, expected)
+		}
+	}
+
+	test("en-u-ca-gregory", "November 10, 2009")
+	test("en-u-ca-japanese", "November 10, 21 Heisei")
+	test("en-u-ca-buddhist", "November 10, 2552 BE")
+	test("en-u-ca-islamic", "Dhuʻl-Qiʻdah 23, 1430 AH")
+	test("en-u-ca-hebrew", "23 Heshvan 5770")
+	test("en-u-ca-persian", "Aban 19, 1388 AP")
+}
+
+func TestFormatDatetimeHourCycle(t *testing.T) {
+	evening := time.Date(2009, time.November, 10, 23, 5, 0, 0, time.UTC)
+	midnight := time.Date(2009, time.November, 10, 0, 5, 0, 0, time.UTC)
+	tz := TZName("UTC")
+
+	test := func(lang string, dateStyle DateFormatStyle, timeStyle DateFormatStyle, t0 time.Time, expected string) {
+		actual, err := FormatDatetime(language.Make(lang), tz, dateStyle, timeStyle, t0)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q", lang, actual, expected)
+		}
+	}
+
+	// U+202F NARROW NO-BREAK SPACE is written as an escape because it is invisible.
+	test("en-US", DateFormatStyleNone, DateFormatStyleShort, evening, "11:05\u202fPM")
+	test("en-US-u-hc-h23", DateFormatStyleNone, DateFormatStyleShort, evening, "23:05")
+	test("en-US-u-hc-h23", DateFormatStyleShort, DateFormatStyleShort, evening, "11/10/09, 23:05")
+	test("en-US-u-hc-h23", DateFormatStyleFull, DateFormatStyleFull, evening, "Tuesday, November 10, 2009 at 23:05:00 Coordinated Universal Time")
+	test("en-US-u-hc-h24", DateFormatStyleNone, DateFormatStyleShort, midnight, "24:05")
+	test("en-GB", DateFormatStyleNone, DateFormatStyleShort, evening, "23:05")
+	test("en-GB-u-hc-h12", DateFormatStyleNone, DateFormatStyleShort, evening, "11:05\u202fpm")
+	test("en-GB-u-hc-h11", DateFormatStyleNone, DateFormatStyleShort, midnight, "0:05\u202fam")
+	test("ja-JP-u-hc-h12", DateFormatStyleShort, DateFormatStyleShort, evening, "2009/11/10 午後11:05")
+	// The date style is untouched.
+	test("en-US-u-hc-h23", DateFormatStyleFull, DateFormatStyleNone, evening, "Tuesday, November 10, 2009")
+}
+
+func TestFormatDatetimeNumberingSystem(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 5, 0, 0, time.UTC)
+	tz := TZName("UTC")
+
+	test := func(lang string, expected string) {
+		actual, err := FormatDatetime(language.Make(lang), tz, DateFormatStyleShort, DateFormatStyleShort, now)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q", lang, actual, expected)
+		}
+	}
+
+	test("en-u-nu-arab-hc-h23", "١١/١٠/٠٩, ٢٣:٠٥")
+	test("en-u-nu-thai-hc-h23", "๑๑/๑๐/๐๙, ๒๓:๐๕")
+}
+
+func TestFormatNumber(t *testing.T) {
+	test := func(lang string, skeleton string, decimal string, expected string) {
+		actual, err := FormatNumber(language.Make(lang), skeleton, decimal)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v %v: %q != %q", lang, skeleton, decimal, actual, expected)
+		}
+	}
+
+	test("en", "", "1234.5", "1,234.5")
+	test("en", "group-off", "1234.5", "1234.5")
+	test("en", ".00", "1.5", "1.50")
+	test("de", "", "1234.5", "1.234,5")
+	test("en-u-nu-arab", "group-off precision-integer", "1234", "١٢٣٤")
+	test("en-u-nu-thai", "", "1234", "๑,๒๓๔")
+	test("en", "", "123456789012345678901234567890", "123,456,789,012,345,678,901,234,567,890")
+}
+
+func TestFormatNumberError(t *testing.T) {
+	_, err := FormatNumber(language.English, "nonsense", "1")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+	_, err = FormatNumber(language.English, "", "not a number")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestFormatDateInterval(t *testing.T) {
+	test := func(lang string, skeleton string, start time.Time, end time.Time, expected string) {
+		actual, err := FormatDateInterval(language.Make(lang), TZName("UTC"), skeleton, start, end)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q", lang, skeleton, actual, expected)
+		}
+	}
+
+	jan3 := time.Date(2025, time.January, 3, 9, 0, 0, 0, time.UTC)
+	jan5 := time.Date(2025, time.January, 5, 17, 0, 0, 0, time.UTC)
+	feb5 := time.Date(2025, time.February, 5, 0, 0, 0, 0, time.UTC)
+	test("en", "yMMMd", jan3, jan5, "Jan 3\u2009\u2013\u20095, 2025")
+	test("en", "yMMMd", jan3, feb5, "Jan 3\u2009\u2013\u2009Feb 5, 2025")
+	test("en", "yMMMd", jan3, jan3, "Jan 3, 2025")
+	test("en", "hm", jan3, jan3.Add(2*time.Hour), "9:00\u2009\u2013\u200911:00\u202fAM")
+	test("en", "hm", jan3, jan5, "1/3/2025, 9:00\u202fAM\u2009\u2013\u20091/5/2025, 5:00\u202fPM")
+	test("de", "yMMMd", jan3, jan5, "3.\u20135. Jan. 2025")
+	test("ja", "yMMMd", jan3, jan5, "2025年1月3日～5日")
+}
+
+func TestFormatDateIntervalLocal(t *testing.T) {
+	now := time.Now()
+	_, err := FormatDateInterval(language.Make("en"), TZName("Local"), "yMMMd", now, now)
+	if err != ErrLocalTZ {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	test := func(lang string, style RelativeTimeStyle, numeric bool, unit RelativeTimeUnit, offset float64, expected string) {
+		actual, err := FormatRelativeTime(language.Make(lang), style, numeric, unit, offset)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v %v %v %v: %+q != %+q", lang, style, numeric, unit, offset, actual, expected)
+		}
+	}
+
+	test("en", RelativeTimeStyleLong, true, RelativeTimeUnitDay, -3, "3 days ago")
+	test("en", RelativeTimeStyleLong, true, RelativeTimeUnitHour, 2, "in 2 hours")
+	test("en", RelativeTimeStyleLong, true, RelativeTimeUnitDay, -1, "1 day ago")
+	test("en", RelativeTimeStyleLong, false, RelativeTimeUnitDay, -1, "yesterday")
+	test("en", RelativeTimeStyleLong, false, RelativeTimeUnitSecond, 0, "now")
+	test("en", RelativeTimeStyleShort, true, RelativeTimeUnitMinute, 5, "in 5 min.")
+	test("en", RelativeTimeStyleNarrow, true, RelativeTimeUnitYear, -2, "2y ago")
+	test("de", RelativeTimeStyleLong, false, RelativeTimeUnitDay, 2, "übermorgen")
+	test("ja", RelativeTimeStyleLong, true, RelativeTimeUnitWeek, -1, "1 週間前")
+}
+
+func TestFormatList(t *testing.T) {
+	test := func(lang string, listType ListType, width ListWidth, items []string, expected string) {
+		actual, err := FormatList(language.Make(lang), listType, width, items)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v %v %v: %+q != %+q", lang, listType, width, items, actual, expected)
+		}
+	}
+
+	abc := []string{"A", "B", "C"}
+	test("en", ListTypeConjunction, ListWidthWide, abc, "A, B, and C")
+	test("en", ListTypeConjunction, ListWidthShort, abc, "A, B, & C")
+	test("en", ListTypeDisjunction, ListWidthWide, abc, "A, B, or C")
+	test("en", ListTypeUnit, ListWidthNarrow, []string{"5 ft", "2 in"}, "5 ft 2 in")
+	test("en-GB", ListTypeConjunction, ListWidthWide, abc, "A, B and C")
+	test("de", ListTypeConjunction, ListWidthWide, []string{"Äpfel", "Birnen"}, "Äpfel und Birnen")
+	test("ja", ListTypeConjunction, ListWidthWide, []string{"東京", "大阪", "京都"}, "東京、大阪、京都")
+	test("en", ListTypeConjunction, ListWidthWide, []string{"A", "B"}, "A and B")
+	test("en", ListTypeConjunction, ListWidthWide, []string{"A"}, "A")
+	test("en", ListTypeConjunction, ListWidthWide, nil, "")
+	test("en", ListTypeConjunction, ListWidthWide, []string{strings.Repeat("x", 100), "y"}, strings.Repeat("x", 100)+" and y")
+}
+
+func TestFormatRuleBasedNumber(t *testing.T) {
+	test := func(lang string, style RuleBasedNumberStyle, ruleSet string, decimal string, expected string) {
+		actual, err := FormatRuleBasedNumber(language.Make(lang), style, ruleSet, decimal)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v %v %v: %+q != %+q", lang, style, ruleSet, decimal, actual, expected)
+		}
+	}
+
+	test("en", RuleBasedNumberStyleSpellout, "", "21", "twenty-one")
+	test("en", RuleBasedNumberStyleOrdinal, "", "21", "21st")
+	test("en", RuleBasedNumberStyleSpellout, "%spellout-ordinal", "21", "twenty-first")
+	test("en", RuleBasedNumberStyleSpellout, "", "1.5", "one point five")
+	test("en", RuleBasedNumberStyleSpellout, "", "123456789012345678901234", "123,456,789,012,345,678,901,234")
+	test("en", RuleBasedNumberStyleDuration, "", "3900", "1:05:00")
+	test("en", RuleBasedNumberStyleDuration, "%with-words", "3900", "1 hour, 5 minutes, 0 seconds")
+	test("de", RuleBasedNumberStyleSpellout, "", "21", "ein\u00adund\u00adzwanzig")
+	test("de", RuleBasedNumberStyleOrdinal, "", "21", "21.")
+	test("fr", RuleBasedNumberStyleSpellout, "%spellout-cardinal-feminine", "21", "vingt-et-une")
+	test("ja", RuleBasedNumberStyleSpellout, "", "21", "二十一")
+}
+
+func TestFormatRuleBasedNumberError(t *testing.T) {
+	_, err := FormatRuleBasedNumber(language.English, RuleBasedNumberStyleSpellout, "%no-such-rule-set", "21")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
@@ -0,0 +1,343 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package icu4c
+
+// #cgo pkg-config: icu-i18n icu-uc
+// #include "bridge.h"
+import "C"
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultDateFormatCacheSize is the size of DefaultDateFormatCache.
+const DefaultDateFormatCacheSize = 64
+
+// DefaultDateFormatCache is the DateFormatCache used by FormatDatetime.
+var DefaultDateFormatCache = NewDateFormatCache(DefaultDateFormatCacheSize)
+
+type dateFormatKey struct {
+	Locale    string
+	TZName    TZName
+	DateStyle DateFormatStyle
+	TimeStyle DateFormatStyle
+}
+
+// DateFormatCache is a pool of UDateFormat handles, so that
+// the locale data is not loaded by udat_open on every call.
+// A handle is used by one goroutine at a time.
+// After use, it is kept idle for the next call with the same
+// locale, time zone and styles.
+// At most size handles are kept idle; the least recently used one is closed
+// when the limit is exceeded.
+// It is safe for concurrent use.
+type DateFormatCache struct {
+	handleCache
+}
+
+// NewDateFormatCache creates a DateFormatCache keeping at most size handles idle.
+// If size is 0, every handle is closed after use.
+func NewDateFormatCache(size int) *DateFormatCache {
+	return &DateFormatCache{
+		handleCache: newHandleCache(size, func(handle unsafe.Pointer) {
+			C.udat_close((*C.UDateFormat)(handle))
+		}),
+	}
+}
+
+// FormatDatetime formats t in languageTag and tzName with the styles.
+func (c *DateFormatCache) FormatDatetime(languageTag language.Tag, tzName TZName, dateStyle DateFormatStyle, timeStyle DateFormatStyle, t time.Time) (out string, err error) {
+	if tzName == "Local" {
+		err = ErrLocalTZ
+		return
+	}
+
+	key := dateFormatKey{
+		Locale:    languageTag.String(),
+		TZName:    tzName,
+		DateStyle: dateStyle,
+		TimeStyle: timeStyle,
+	}
+	handle, err := c.open(key)
+	if err != nil {
+		return
+	}
+	defer c.put(key, unsafe.Pointer(handle))
+
+	msec := C.double(t.UnixNano() / int64(time.Millisecond))
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_format_datetime(
+		handle,
+		msec,
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+// Flush closes the idle handles.
+func (c *DateFormatCache) Flush() {
+	c.handleCache.Flush()
+}
+
+// Close closes the idle handles.
+// After Close, every handle is closed after use,
+// so c remains usable but no longer caches.
+func (c *DateFormatCache) Close() {
+	c.handleCache.Close()
+}
+
+func (c *DateFormatCache) open(key dateFormatKey) (handle *C.UDateFormat, err error) {
+	if idle, ok := c.get(key); ok {
+		return (*C.UDateFormat)(idle), nil
+	}
+
+	// Open outside of the lock because udat_open is slow.
+	cLocale := C.CString(key.Locale)
+	cTZ := C.CString(string(key.TZName))
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(cTZ))
+	}()
+
+	status := C.go_open_datetime_format(
+		cLocale,
+		cTZ,
+		C.UDateFormatStyle(key.DateStyle),
+		C.UDateFormatStyle(key.TimeStyle),
+		&handle,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+	return
+}
+
+// DefaultPluralRulesCacheSize is the size of DefaultPluralRulesCache.
+const DefaultPluralRulesCacheSize = 64
+
+// DefaultPluralRulesCache is the PluralRulesCache used by PluralSelectFormatted.
+var DefaultPluralRulesCache = NewPluralRulesCache(DefaultPluralRulesCacheSize)
+
+type pluralRulesKey struct {
+	Locale     string
+	PluralType PluralType
+	Skeleton   string
+}
+
+// PluralRulesCache is a pool of UPluralRules and UNumberFormatter handles, so that
+// the locale data is not loaded by uplrules_openForType and
+// unumf_openForSkeletonAndLocale on every call.
+// It caches the handles like DateFormatCache, by locale, plural type and skeleton.
+// It is safe for concurrent use.
+type PluralRulesCache struct {
+	handleCache
+}
+
+// NewPluralRulesCache creates a PluralRulesCache keeping at most size handles idle.
+// If size is 0, every handle is closed after use.
+func NewPluralRulesCache(size int) *PluralRulesCache {
+	return &PluralRulesCache{
+		handleCache: newHandleCache(size, func(handle unsafe.Pointer) {
+			C.go_close_plural_formatter((*C.GoPluralFormatter)(handle))
+		}),
+	}
+}
+
+// PluralSelectFormatted selects the plural keyword of decimal formatted with
+// the number skeleton with uplrules_selectFormatted.
+func (c *PluralRulesCache) PluralSelectFormatted(languageTag language.Tag, pluralType PluralType, skeleton string, decimal string) (out string, err error) {
+	key := pluralRulesKey{
+		Locale:     languageTag.String(),
+		PluralType: pluralType,
+		Skeleton:   skeleton,
+	}
+	handle, err := c.open(key)
+	if err != nil {
+		return
+	}
+	defer c.put(key, unsafe.Pointer(handle))
+
+	cDecimal := C.CString(decimal)
+	var result *C.char
+	var resultLength C.int32_t
+
+	defer func() {
+		C.free(unsafe.Pointer(cDecimal))
+		C.free(unsafe.Pointer(result))
+	}()
+
+	status := C.go_plural_select_formatted(
+		handle,
+		cDecimal,
+		&result,
+		&resultLength,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+
+	out = C.GoStringN(result, C.int(resultLength))
+	return
+}
+
+// Flush closes the idle handles.
+func (c *PluralRulesCache) Flush() {
+	c.handleCache.Flush()
+}
+
+// Close closes the idle handles.
+// After Close, every handle is closed after use,
+// so c remains usable but no longer caches.
+func (c *PluralRulesCache) Close() {
+	c.handleCache.Close()
+}
+
+func (c *PluralRulesCache) open(key pluralRulesKey) (handle *C.GoPluralFormatter, err error) {
+	if idle, ok := c.get(key); ok {
+		return (*C.GoPluralFormatter)(idle), nil
+	}
+
+	cLocale := C.CString(key.Locale)
+	cSkeleton := C.CString(key.Skeleton)
+
+	defer func() {
+		C.free(unsafe.Pointer(cLocale))
+		C.free(unsafe.Pointer(cSkeleton))
+	}()
+
+	status := C.go_open_plural_formatter(
+		cLocale,
+		C.UPluralType(key.PluralType),
+		cSkeleton,
+		&handle,
+	)
+	if status != 0 {
+		err = fmt.Errorf("icu4c: %v", status)
+		return
+	}
+	return
+}
+
+type idleHandle struct {
+	Key    interface{}
+	Handle unsafe.Pointer
+}
+
+// handleCache keeps at most size idle handles of icu4c,
+// closing the least recently used one with closeHandle.
+type handleCache struct {
+	mutex       sync.Mutex
+	size        int
+	closed      bool
+	closeHandle func(handle unsafe.Pointer)
+	// lru holds *idleHandle with the most recently used at the front.
+	lru *list.List
+	// idle maps a key to its elements in lru, the most recently used last.
+	idle map[interface{}][]*list.Element
+}
+
+func newHandleCache(size int, closeHandle func(handle unsafe.Pointer)) handleCache {
+	return handleCache{
+		size:        size,
+		closeHandle: closeHandle,
+		lru:         list.New(),
+		idle:        make(map[interface{}][]*list.Element),
+	}
+}
+
+func (c *handleCache) Flush() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.flush()
+}
+
+func (c *handleCache) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.closed = true
+	c.flush()
+}
+
+func (c *handleCache) flush() {
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		c.closeHandle(e.Value.(*idleHandle).Handle)
+	}
+	c.lru.Init()
+	c.idle = make(map[interface{}][]*list.Element)
+}
+
+// get takes an idle handle of key.
+// If there is none, the caller opens a new handle.
+func (c *handleCache) get(key interface{}) (handle unsafe.Pointer, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elements := c.idle[key]
+	if len(elements) == 0 {
+		return nil, false
+	}
+	e := elements[len(elements)-1]
+	c.removeIdle(key, len(elements)-1)
+	c.lru.Remove(e)
+	return e.Value.(*idleHandle).Handle, true
+}
+
+func (c *handleCache) put(key interface{}, handle unsafe.Pointer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed || c.size <= 0 {
+		c.closeHandle(handle)
+		return
+	}
+
+	e := c.lru.PushFront(&idleHandle{
+		Key:    key,
+		Handle: handle,
+	})
+	c.idle[key] = append(c.idle[key], e)
+
+	if c.lru.Len() > c.size {
+		// The back is the least recently used of its key too.
+		oldest := c.lru.Back()
+		idle := oldest.Value.(*idleHandle)
+		c.removeIdle(idle.Key, 0)
+		c.lru.Remove(oldest)
+		c.closeHandle(idle.Handle)
+	}
+}
+
+func (c *handleCache) removeIdle(key interface{}, i int) {
+	elements := c.idle[key]
+	elements = append(elements[:i], elements[i+1:]...)
+	if len(elements) == 0 {
+		delete(c.idle, key)
+	} else {
+		c.idle[key] = elements
+	}
+}
@@ -0,0 +1,227 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package icu4c
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestDateFormatCache(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	tz := TZName("Asia/Hong_Kong")
+	c := NewDateFormatCache(2)
+
+	test := func(lang string, expected string, idle int) {
+		actual, err := c.FormatDatetime(language.Make(lang), tz, DateFormatStyleFull, DateFormatStyleNone, now)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v != %v", actual, expected)
+		}
+		if c.lru.Len() != idle {
+			t.Errorf("%v: idle %v != %v", lang, c.lru.Len(), idle)
+		}
+	}
+
+	test("zh-Hant-HK", "2009年11月11日星期三", 1)
+	test("zh-Hant-HK", "2009年11月11日星期三", 1)
+	test("en", "Wednesday, November 11, 2009", 2)
+	test("ja", "2009年11月11日水曜日", 2)
+	if _, ok := c.idle[dateFormatKey{"zh-Hant-HK", tz, DateFormatStyleFull, DateFormatStyleNone}]; ok {
+		t.Errorf("expected the least recently used handle to be closed")
+	}
+
+	c.Flush()
+	if c.lru.Len() != 0 || len(c.idle) != 0 {
+		t.Errorf("expected no idle handles after Flush")
+	}
+	test("en", "Wednesday, November 11, 2009", 1)
+
+	c.Close()
+	test("en", "Wednesday, November 11, 2009", 0)
+}
+
+func TestDateFormatCacheError(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	c := NewDateFormatCache(2)
+
+	_, err := c.FormatDatetime(language.English, TZName("Local"), DateFormatStyleFull, DateFormatStyleNone, now)
+	if err != ErrLocalTZ {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if c.lru.Len() != 0 {
+		t.Errorf("expected no idle handles after error")
+	}
+}
+
+func TestDateFormatCacheConcurrent(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	tz := TZName("Asia/Hong_Kong")
+	c := NewDateFormatCache(4)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				actual, err := c.FormatDatetime(language.English, tz, DateFormatStyleFull, DateFormatStyleNone, now)
+				if err != nil {
+					t.Errorf("err: %v", err)
+				} else if actual != "Wednesday, November 11, 2009" {
+					t.Errorf("unexpected: %v", actual)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.lru.Len() > 4 {
+		t.Errorf("idle %v > 4", c.lru.Len())
+	}
+}
+
+func benchmarkFormatDatetime(b *testing.B, c *DateFormatCache) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	tz := TZName("Asia/Hong_Kong")
+	languageTag := language.Make("zh-Hant-HK")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := c.FormatDatetime(languageTag, tz, DateFormatStyleFull, DateFormatStyleFull, now)
+		if err != nil {
+			b.Fatalf("err: %v", err)
+		}
+	}
+}
+
+func BenchmarkFormatDatetimeCached(b *testing.B) {
+	c := NewDateFormatCache(DefaultDateFormatCacheSize)
+	defer c.Close()
+	benchmarkFormatDatetime(b, c)
+}
+
+func BenchmarkFormatDatetimeUncached(b *testing.B) {
+	benchmarkFormatDatetime(b, NewDateFormatCache(0))
+}
+
+func BenchmarkFormatDatetimeCachedParallel(b *testing.B) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	tz := TZName("Asia/Hong_Kong")
+	languageTag := language.Make("zh-Hant-HK")
+	c := NewDateFormatCache(DefaultDateFormatCacheSize)
+	defer c.Close()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := c.FormatDatetime(languageTag, tz, DateFormatStyleFull, DateFormatStyleFull, now)
+			if err != nil {
+				b.Fatalf("err: %v", err)
+			}
+		}
+	})
+}
+
+func TestPluralRulesCache(t *testing.T) {
+	c := NewPluralRulesCache(2)
+
+	test := func(lang string, skeleton string, decimal string, expected string, idle int) {
+		actual, err := c.PluralSelectFormatted(language.Make(lang), PluralTypeCardinal, skeleton, decimal)
+		if err != nil {
+			t.Errorf("err: %v", err)
+		} else if actual != expected {
+			t.Errorf("%v %v %v: %v != %v", lang, skeleton, decimal, actual, expected)
+		}
+		if c.lru.Len() != idle {
+			t.Errorf("%v %v: idle %v != %v", lang, skeleton, c.lru.Len(), idle)
+		}
+	}
+
+	test("en", "precision-integer", "1", "one", 1)
+	test("en", "precision-integer", "2", "other", 1)
+	test("en", ".0", "1", "other", 2)
+	test("fr", "compact-short precision-unlimited", "1200000", "many", 2)
+	if _, ok := c.idle[pluralRulesKey{"en", PluralTypeCardinal, "precision-integer"}]; ok {
+		t.Errorf("expected the least recently used handle to be closed")
+	}
+
+	c.Flush()
+	if c.lru.Len() != 0 || len(c.idle) != 0 {
+		t.Errorf("expected no idle handles after Flush")
+	}
+	test("en", "precision-integer", "1", "one", 1)
+
+	c.Close()
+	test("en", "precision-integer", "1", "one", 0)
+}
+
+func TestPluralRulesCacheError(t *testing.T) {
+	c := NewPluralRulesCache(2)
+
+	_, err := c.PluralSelectFormatted(language.English, PluralTypeCardinal, "nonsense", "1")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+	_, err = c.PluralSelectFormatted(language.English, PluralTypeCardinal, "precision-integer", "nonsense")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+	// The handle is still usable after the invalid decimal.
+	if c.lru.Len() != 1 {
+		t.Errorf("idle %v != 1", c.lru.Len())
+	}
+}
+
+func TestPluralRulesCacheConcurrent(t *testing.T) {
+	c := NewPluralRulesCache(4)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				actual, err := c.PluralSelectFormatted(language.English, PluralTypeOrdinal, "precision-integer", "23")
+				if err != nil {
+					t.Errorf("err: %v", err)
+				} else if actual != "few" {
+					t.Errorf("unexpected: %v", actual)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.lru.Len() > 4 {
+		t.Errorf("idle %v > 4", c.lru.Len())
+	}
+}
+
+func benchmarkPluralSelectFormatted(b *testing.B, c *PluralRulesCache) {
+	languageTag := language.Make("fr")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := c.PluralSelectFormatted(languageTag, PluralTypeCardinal, "compact-short precision-unlimited", "1200000")
+		if err != nil {
+			b.Fatalf("err: %v", err)
+		}
+	}
+}
+
+func BenchmarkPluralSelectFormattedCached(b *testing.B) {
+	c := NewPluralRulesCache(DefaultPluralRulesCacheSize)
+	defer c.Close()
+	benchmarkPluralSelectFormatted(b, c)
+}
+
+func BenchmarkPluralSelectFormattedUncached(b *testing.B) {
+	benchmarkPluralSelectFormatted(b, NewPluralRulesCache(0))
+}
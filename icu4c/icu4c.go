@@ -0,0 +1,18 @@
+// Package icu4c formats with icu4c through cgo.
+//
+// When cgo is disabled, or the build tag purego is set,
+// the functions return ErrUnsupported instead.
+package icu4c
+
+import (
+	"errors"
+)
+
+var ErrLocalTZ = errors.New("icu4c: Go Local tz name is not supported")
+
+// ErrUnsupported is returned when the package is built without icu4c.
+var ErrUnsupported = errors.New("icu4c: not supported without cgo")
+
+// TZName is the tz database name such as "Asia/Hong_Kong".
+// Note that this is different from the name returned by time.Zone().
+type TZName string
@@ -0,0 +1,191 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package icu4c
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// DateFormatStyle has the values of UDateFormatStyle.
+type DateFormatStyle int
+
+const (
+	DateFormatStyleNone   DateFormatStyle = -1
+	DateFormatStyleShort  DateFormatStyle = 3
+	DateFormatStyleMedium DateFormatStyle = 2
+	DateFormatStyleLong   DateFormatStyle = 1
+	DateFormatStyleFull   DateFormatStyle = 0
+)
+
+// PluralType has the values of UPluralType.
+type PluralType int
+
+const (
+	PluralTypeCardinal PluralType = 0
+	PluralTypeOrdinal  PluralType = 1
+)
+
+// RelativeTimeStyle has the values of UDateRelativeDateTimeFormatterStyle.
+type RelativeTimeStyle int
+
+const (
+	RelativeTimeStyleLong   RelativeTimeStyle = 0
+	RelativeTimeStyleShort  RelativeTimeStyle = 1
+	RelativeTimeStyleNarrow RelativeTimeStyle = 2
+)
+
+// RelativeTimeUnit has the values of URelativeDateTimeUnit.
+type RelativeTimeUnit int
+
+const (
+	RelativeTimeUnitYear    RelativeTimeUnit = 0
+	RelativeTimeUnitQuarter RelativeTimeUnit = 1
+	RelativeTimeUnitMonth   RelativeTimeUnit = 2
+	RelativeTimeUnitWeek    RelativeTimeUnit = 3
+	RelativeTimeUnitDay     RelativeTimeUnit = 4
+	RelativeTimeUnitHour    RelativeTimeUnit = 5
+	RelativeTimeUnitMinute  RelativeTimeUnit = 6
+	RelativeTimeUnitSecond  RelativeTimeUnit = 7
+)
+
+// ListType has the values of UListFormatterType.
+type ListType int
+
+const (
+	ListTypeConjunction ListType = 0
+	ListTypeDisjunction ListType = 1
+	ListTypeUnit        ListType = 2
+)
+
+// ListWidth has the values of UListFormatterWidth.
+type ListWidth int
+
+const (
+	ListWidthWide   ListWidth = 0
+	ListWidthShort  ListWidth = 1
+	ListWidthNarrow ListWidth = 2
+)
+
+// RuleBasedNumberStyle has the values of UNumberFormatStyle.
+type RuleBasedNumberStyle int
+
+const (
+	RuleBasedNumberStyleSpellout RuleBasedNumberStyle = 5
+	RuleBasedNumberStyleOrdinal  RuleBasedNumberStyle = 6
+	RuleBasedNumberStyleDuration RuleBasedNumberStyle = 7
+)
+
+// FormatDatetime returns ErrUnsupported.
+func FormatDatetime(languageTag language.Tag, tzName TZName, dateStyle DateFormatStyle, timeStyle DateFormatStyle, t time.Time) (out string, err error) {
+	return DefaultDateFormatCache.FormatDatetime(languageTag, tzName, dateStyle, timeStyle, t)
+}
+
+// PluralSelect returns ErrUnsupported.
+func PluralSelect(languageTag language.Tag, pluralType PluralType, number float64) (out string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// PluralSelectFormatted returns ErrUnsupported.
+func PluralSelectFormatted(languageTag language.Tag, pluralType PluralType, skeleton string, decimal string) (out string, err error) {
+	return DefaultPluralRulesCache.PluralSelectFormatted(languageTag, pluralType, skeleton, decimal)
+}
+
+// PluralKeywords returns ErrUnsupported.
+func PluralKeywords(languageTag language.Tag, pluralType PluralType) (out []string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// FormatNumber returns ErrUnsupported.
+func FormatNumber(languageTag language.Tag, skeleton string, decimal string) (out string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// FormatDateInterval returns ErrUnsupported.
+func FormatDateInterval(languageTag language.Tag, tzName TZName, skeleton string, start time.Time, end time.Time) (out string, err error) {
+	if tzName == "Local" {
+		err = ErrLocalTZ
+		return
+	}
+	err = ErrUnsupported
+	return
+}
+
+// FormatRelativeTime returns ErrUnsupported.
+func FormatRelativeTime(languageTag language.Tag, style RelativeTimeStyle, numeric bool, unit RelativeTimeUnit, offset float64) (out string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// FormatList returns ErrUnsupported.
+func FormatList(languageTag language.Tag, listType ListType, width ListWidth, items []string) (out string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// FormatRuleBasedNumber returns ErrUnsupported.
+func FormatRuleBasedNumber(languageTag language.Tag, style RuleBasedNumberStyle, ruleSet string, decimal string) (out string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// DefaultDateFormatCacheSize is the size of DefaultDateFormatCache.
+const DefaultDateFormatCacheSize = 64
+
+// DefaultDateFormatCache is the DateFormatCache used by FormatDatetime.
+var DefaultDateFormatCache = NewDateFormatCache(DefaultDateFormatCacheSize)
+
+// DateFormatCache caches nothing without icu4c.
+type DateFormatCache struct{}
+
+// NewDateFormatCache creates a DateFormatCache.
+func NewDateFormatCache(size int) *DateFormatCache {
+	return &DateFormatCache{}
+}
+
+// FormatDatetime returns ErrUnsupported.
+func (c *DateFormatCache) FormatDatetime(languageTag language.Tag, tzName TZName, dateStyle DateFormatStyle, timeStyle DateFormatStyle, t time.Time) (out string, err error) {
+	if tzName == "Local" {
+		err = ErrLocalTZ
+		return
+	}
+	err = ErrUnsupported
+	return
+}
+
+// Flush does nothing.
+func (c *DateFormatCache) Flush() {}
+
+// Close does nothing.
+func (c *DateFormatCache) Close() {}
+
+// DefaultPluralRulesCacheSize is the size of DefaultPluralRulesCache.
+const DefaultPluralRulesCacheSize = 64
+
+// DefaultPluralRulesCache is the PluralRulesCache used by PluralSelectFormatted.
+var DefaultPluralRulesCache = NewPluralRulesCache(DefaultPluralRulesCacheSize)
+
+// PluralRulesCache caches nothing without icu4c.
+type PluralRulesCache struct{}
+
+// NewPluralRulesCache creates a PluralRulesCache.
+func NewPluralRulesCache(size int) *PluralRulesCache {
+	return &PluralRulesCache{}
+}
+
+// PluralSelectFormatted returns ErrUnsupported.
+func (c *PluralRulesCache) PluralSelectFormatted(languageTag language.Tag, pluralType PluralType, skeleton string, decimal string) (out string, err error) {
+	err = ErrUnsupported
+	return
+}
+
+// Flush does nothing.
+func (c *PluralRulesCache) Flush() {}
+
+// Close does nothing.
+func (c *PluralRulesCache) Close() {}
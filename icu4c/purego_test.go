@@ -0,0 +1,65 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package icu4c
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestUnsupported(t *testing.T) {
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+	_, err := FormatDatetime(language.English, TZName("UTC"), DateFormatStyleFull, DateFormatStyleNone, now)
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = FormatDatetime(language.English, TZName("Local"), DateFormatStyleFull, DateFormatStyleNone, now)
+	if err != ErrLocalTZ {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = PluralSelect(language.English, PluralTypeCardinal, 1)
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = PluralSelectFormatted(language.English, PluralTypeCardinal, "precision-integer", "1")
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = NewPluralRulesCache(1).PluralSelectFormatted(language.English, PluralTypeCardinal, "precision-integer", "1")
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = PluralKeywords(language.English, PluralTypeCardinal)
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = FormatDateInterval(language.English, TZName("UTC"), "yMMMd", now, now)
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = FormatRelativeTime(language.English, RelativeTimeStyleLong, true, RelativeTimeUnitDay, -1)
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = FormatList(language.English, ListTypeConjunction, ListWidthWide, []string{"a", "b"})
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = FormatRuleBasedNumber(language.English, RuleBasedNumberStyleSpellout, "", "21")
+	if err != ErrUnsupported {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
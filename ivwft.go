@@ -3,42 +3,93 @@ package messageformat
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
 )
 
+// Operands are the plural operands of a number according to
+// https://unicode.org/reports/tr35/tr35-numbers.html#Operands
+type Operands struct {
+	// I is the integer digits of n.
+	I int
+	// V is the number of visible fraction digits in n, with trailing zeros.
+	V int
+	// W is the number of visible fraction digits in n, without trailing zeros.
+	W int
+	// F is the visible fraction digits in n, with trailing zeros.
+	F int
+	// T is the visible fraction digits in n, without trailing zeros.
+	T int
+	// C is the compact decimal exponent value,
+	// that is the power of 10 of a compact number like "1.2 M".
+	C int
+	// E is a deprecated synonym of C. It always equals C.
+	E int
+
+	// decimal is n as a plain decimal string.
+	// Unlike I and F, its digits are never reduced.
+	decimal string
+}
+
 // IVWFT derives i, v, w, f, t from number according to
 // https://unicode.org/reports/tr35/tr35-numbers.html#Operands
 func IVWFT(number interface{}) (i, v, w, f, t int, err error) {
+	ops, err := PluralOperands(number)
+	if err != nil {
+		return
+	}
+	return ops.I, ops.V, ops.W, ops.F, ops.T, nil
+}
+
+// PluralOperands derives all plural operands from number.
+// Besides the numeric types, number can be a string in compact notation
+// like "1.2c6", which is 1200000 with the compact decimal exponent 6.
+func PluralOperands(number interface{}) (ops Operands, err error) {
 	switch value := number.(type) {
 	case int8:
-		return ivwftInt(absInt(int64(value)))
+		return operandsInt(absInt(int64(value)))
 	case int16:
-		return ivwftInt(absInt(int64(value)))
+		return operandsInt(absInt(int64(value)))
 	case int32:
-		return ivwftInt(absInt(int64(value)))
+		return operandsInt(absInt(int64(value)))
 	case int64:
-		return ivwftInt(absInt(value))
+		return operandsInt(absInt(value))
 	case int:
-		return ivwftInt(absInt(int64(value)))
+		return operandsInt(absInt(int64(value)))
 	case uint8:
-		return ivwftInt(uint64(value))
+		return operandsInt(uint64(value))
 	case uint16:
-		return ivwftInt(uint64(value))
+		return operandsInt(uint64(value))
 	case uint32:
-		return ivwftInt(uint64(value))
+		return operandsInt(uint64(value))
 	case uint64:
-		return ivwftInt(value)
+		return operandsInt(value)
 	case uint:
-		return ivwftInt(uint64(value))
+		return operandsInt(uint64(value))
 	case float32:
-		return ivwftFloat(float64(value))
+		return operandsFloat(float64(value))
 	case float64:
-		return ivwftFloat(value)
+		return operandsFloat(value)
 	case string:
-		return ivwftString(value)
-	default:
+		return operandsString(value)
+	case *big.Int, *big.Float, *big.Rat, Decimal, Number:
+		var s string
+		s, _, err = bigDecimalString(value)
+		if err != nil {
+			return
+		}
+		return operandsString(s)
+	case bool:
 		err = fmt.Errorf("unsupported type: %T", number)
+	default:
+		var b interface{}
+		b, err = builtinValue(number)
+		if err != nil {
+			err = fmt.Errorf("unsupported type: %T", number)
+			return
+		}
+		return PluralOperands(b)
 	}
 	return
 }
@@ -52,49 +103,106 @@ func absInt(number int64) uint64 {
 	return uint64(number)
 }
 
+// maxOperandDigits is the number of digits of an operand that fits in int64.
+const maxOperandDigits = 18
+
 func parseInt(s string) (i int, err error) {
 	if s == "" {
 		return
 	}
-	return strconv.Atoi(s)
+	if len(s) <= maxOperandDigits || !isDigits(s) {
+		return strconv.Atoi(s)
+	}
+	s = strings.TrimLeft(s, "0")
+	if len(s) <= maxOperandDigits {
+		return parseInt(s)
+	}
+	// An operand that does not fit in int is reduced to 10^18 plus its last 18 digits.
+	// Plural rules only test operands modulo powers of 10 up to 10^6,
+	// or against small ranges, so the plural form is unchanged.
+	i, err = strconv.Atoi("1" + s[len(s)-maxOperandDigits:])
+	return
 }
 
-func ivwftInt(number uint64) (i, v, w, f, t int, err error) {
-	return ivwftString(strconv.FormatUint(number, 10))
+func operandsInt(number uint64) (ops Operands, err error) {
+	return operandsString(strconv.FormatUint(number, 10))
 }
 
-func ivwftFloat(number float64) (i, v, w, f, t int, err error) {
-	return ivwftString(strconv.FormatFloat(math.Abs(number), 'f', -1, 64))
+func operandsFloat(number float64) (ops Operands, err error) {
+	return operandsString(strconv.FormatFloat(math.Abs(number), 'f', -1, 64))
 }
 
-func ivwftString(number string) (i, v, w, f, t int, err error) {
+// splitCompact splits number into the plain decimal and the compact decimal exponent.
+// The exponent is introduced by c, or the deprecated e.
+func splitCompact(number string) (plain string, c int, err error) {
+	idx := strings.IndexAny(number, "ce")
+	if idx == -1 {
+		plain = number
+		return
+	}
+
+	mantissa := number[:idx]
+	c, err = strconv.Atoi(number[idx+1:])
+	if err != nil {
+		return
+	}
+	if c < 0 {
+		err = fmt.Errorf("negative compact exponent: %v", number)
+		return
+	}
+
+	integral := mantissa
+	fraction := ""
+	if idx := strings.IndexByte(mantissa, '.'); idx != -1 {
+		integral = mantissa[:idx]
+		fraction = mantissa[idx+1:]
+	}
+
+	// Move the decimal point c places to the right.
+	if len(fraction) <= c {
+		plain = integral + fraction + strings.Repeat("0", c-len(fraction))
+	} else {
+		plain = integral + fraction[:c] + "." + fraction[c:]
+	}
+	return
+}
+
+func operandsString(number string) (ops Operands, err error) {
 	if strings.HasPrefix(number, "-") {
 		number = number[1:]
 	}
 
+	number, c, err := splitCompact(number)
+	if err != nil {
+		return
+	}
+	ops.C = c
+	ops.E = c
+	ops.decimal = number
+
 	idx := strings.IndexRune(number, '.')
 	if idx == -1 {
-		i, err = parseInt(number)
+		ops.I, err = parseInt(number)
 		return
 	}
 
 	integral := number[0:idx]
 	fraction := number[idx+1:]
 
-	i, err = parseInt(integral)
+	ops.I, err = parseInt(integral)
 	if err != nil {
 		return
 	}
 
-	v = len(fraction)
-	w = len(strings.TrimRight(fraction, "0"))
+	ops.V = len(fraction)
+	ops.W = len(strings.TrimRight(fraction, "0"))
 
-	f, err = parseInt(strings.TrimLeft(fraction, "0"))
+	ops.F, err = parseInt(strings.TrimLeft(fraction, "0"))
 	if err != nil {
 		return
 	}
 
-	t, err = parseInt(strings.TrimRight(strings.TrimLeft(fraction, "0"), "0"))
+	ops.T, err = parseInt(strings.TrimRight(strings.TrimLeft(fraction, "0"), "0"))
 	if err != nil {
 		return
 	}
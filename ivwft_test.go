@@ -1,6 +1,7 @@
 package messageformat
 
 import (
+	"math/big"
 	"testing"
 )
 
@@ -64,4 +65,52 @@ func TestIVWFT(t_ *testing.T) {
 	test(-1234.0, 1234, 0, 0, 0, 0)
 	test("1234.0", 1234, 1, 0, 0, 0)
 	test("-1234.0", 1234, 1, 0, 0, 0)
+
+	test(testUserID(-1234), 1234, 0, 0, 0, 0)
+	test(testGender("1.30"), 1, 2, 1, 30, 3)
+	test(testStringer{"1.30"}, 1, 2, 1, 30, 3)
+	test(testTextMarshaler{Value: "1.30"}, 1, 2, 1, 30, 3)
+
+	// Operands that do not fit in int are reduced.
+	test(uint64(18446744073709551615), 1446744073709551615, 0, 0, 0, 0)
+	test("1000000000000000000000000", 1000000000000000000, 0, 0, 0, 0)
+	test("1.0000000000000000000000001", 1, 25, 25, 1, 1)
+	test("1.1000000000000000000000001", 1, 25, 25, 1000000000000000001, 1000000000000000001)
+	test("0000000000000000000000001", 1, 0, 0, 0, 0)
+	test(big.NewInt(21), 21, 0, 0, 0, 0)
+	test(big.NewRat(5, 4), 1, 2, 2, 25, 25)
+	test(testDecimal("1.50"), 1, 2, 1, 50, 5)
+}
+
+func TestPluralOperands(t *testing.T) {
+	test := func(input interface{}, expected Operands) {
+		actual, err := PluralOperands(input)
+		if err != nil {
+			t.Errorf("%#v: err: %v\n", input, err)
+		} else if actual != expected {
+			t.Errorf("%#v: %#v != %#v\n", input, actual, expected)
+		}
+	}
+
+	test(1, Operands{I: 1, decimal: "1"})
+	test("1.30", Operands{I: 1, V: 2, W: 1, F: 30, T: 3, decimal: "1.30"})
+
+	// Compact notation.
+	test("1c3", Operands{I: 1000, C: 3, E: 3, decimal: "1000"})
+	test("1.2c6", Operands{I: 1200000, C: 6, E: 6, decimal: "1200000"})
+	test("-1.2c6", Operands{I: 1200000, C: 6, E: 6, decimal: "1200000"})
+	test("1.2345c2", Operands{I: 123, V: 2, W: 2, F: 45, T: 45, C: 2, E: 2, decimal: "123.45"})
+	test("1.2e6", Operands{I: 1200000, C: 6, E: 6, decimal: "1200000"})
+	test("1c0", Operands{I: 1, decimal: "1"})
+
+	testError := func(input interface{}) {
+		_, err := PluralOperands(input)
+		if err == nil {
+			t.Errorf("%#v: expected error\n", input)
+		}
+	}
+
+	testError("1c")
+	testError("1c-1")
+	testError(true)
 }
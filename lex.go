@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"unicode"
 )
 
@@ -25,6 +26,8 @@ const (
 	TokenTypeEqual
 	TokenTypePound
 	TokenTypeColon
+	TokenTypeTagOpen
+	TokenTypeTagClose
 )
 
 type Token struct {
@@ -54,6 +57,10 @@ func (t Token) String() string {
 		return "#"
 	case TokenTypeColon:
 		return ":"
+	case TokenTypeTagOpen:
+		return "<" + t.Value + ">"
+	case TokenTypeTagClose:
+		return "</" + t.Value + ">"
 	default:
 		panic("unreachable")
 	}
@@ -65,7 +72,9 @@ type lexer struct {
 	// arg tells whether the next lex call is LexText or LexArg.
 	arg             bool
 	isInPluralStyle func() bool
-	Output          []Token
+	// markup tells whether <tag> and </tag> are recognized in text.
+	markup bool
+	Output []Token
 }
 
 func newLexer(s string) *lexer {
@@ -127,6 +136,21 @@ func (l *lexer) LexText(buf *bytes.Buffer) error {
 			} else {
 				buf.WriteByte(ch)
 			}
+		case '<':
+			if !l.markup {
+				buf.WriteByte(ch)
+				continue
+			}
+			// < is a literal unless a well-formed tag follows.
+			tag, n := scanTag(l.input.Bytes())
+			if n == 0 {
+				buf.WriteByte(ch)
+				continue
+			}
+			l.input.Next(n)
+			l.outText(buf.String())
+			l.Output = append(l.Output, tag...)
+			return nil
 		default:
 			buf.WriteByte(ch)
 		}
@@ -216,6 +240,46 @@ func (l *lexer) LexArg() error {
 	}
 }
 
+// LexArgStyle lexes the style text of an argument,
+// that is everything up to the } closing the argument.
+// Braces in the style text must be balanced,
+// unless they are quoted by apostrophes.
+// The style text is output verbatim without surrounding whitespace.
+func (l *lexer) LexArgStyle() error {
+	var buf bytes.Buffer
+	depth := 0
+	quoted := false
+	for {
+		ch, err := l.input.ReadByte()
+		if errors.Is(err, io.EOF) {
+			if quoted {
+				return ErrUnterminatedQuotedString
+			}
+			l.outText(strings.TrimSpace(buf.String()))
+			l.out(TokenTypeEOF)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch {
+		case ch == '\'':
+			quoted = !quoted
+		case quoted:
+		case ch == '{':
+			depth++
+		case ch == '}' && depth > 0:
+			depth--
+		case ch == '}':
+			l.outText(strings.TrimSpace(buf.String()))
+			l.out(TokenTypeRBrace)
+			l.arg = false
+			return nil
+		}
+		buf.WriteByte(ch)
+	}
+}
+
 func (l *lexer) lexNumber(buf *bytes.Buffer) error {
 	for {
 		ch, err := l.input.ReadByte()
@@ -261,6 +325,42 @@ func (l *lexer) lexWord(buf *bytes.Buffer) error {
 	}
 }
 
+// scanTag scans <name>, </name> or <name/> in b, which follows <.
+// The self-closing <name/> is the same as <name></name>.
+// n is the number of bytes of the tag after <, or 0 if b does not start a tag.
+func scanTag(b []byte) (tokens []Token, n int) {
+	typ := TokenTypeTagOpen
+	i := 0
+	if i < len(b) && b[i] == '/' {
+		typ = TokenTypeTagClose
+		i++
+	}
+	start := i
+	if i >= len(b) || !((b[i] >= 'a' && b[i] <= 'z') || (b[i] >= 'A' && b[i] <= 'Z')) {
+		return nil, 0
+	}
+	for i < len(b) && isTagNameByte(b[i]) {
+		i++
+	}
+	name := string(b[start:i])
+
+	switch {
+	case i < len(b) && b[i] == '>':
+		return []Token{{Type: typ, Value: name}}, i + 1
+	case typ == TokenTypeTagOpen && i+1 < len(b) && b[i] == '/' && b[i+1] == '>':
+		return []Token{
+			{Type: TokenTypeTagOpen, Value: name},
+			{Type: TokenTypeText},
+			{Type: TokenTypeTagClose, Value: name},
+		}, i + 2
+	}
+	return nil, 0
+}
+
+func isTagNameByte(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == '_' || ch == '-'
+}
+
 func (l *lexer) outText(s string) {
 	l.Output = append(l.Output, Token{Type: TokenTypeText, Value: s})
 }
@@ -116,6 +116,65 @@ func TestLexText(t *testing.T) {
 		`"'' "`)
 }
 
+func TestLexTextMarkup(t *testing.T) {
+	lexMarkup := func(input string, tokens ...string) {
+		l := newLexer(input)
+		l.markup = true
+		var actual []string
+		var err error
+	loop:
+		for {
+			err = l.LexText(&bytes.Buffer{})
+			if err != nil {
+				break
+			}
+			for _, token := range l.Output {
+				if token.Type == TokenTypeEOF {
+					break loop
+				}
+				actual = append(actual, token.String())
+			}
+			l.Output = nil
+		}
+
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		}
+
+		if !reflect.DeepEqual(actual, tokens) {
+			t.Errorf("expected: %v\n", tokens)
+			t.Errorf("actual: %v\n", actual)
+		}
+	}
+
+	lexMarkup("<b>bold</b>",
+		`""`, "<b>", `"bold"`, "</b>", `""`)
+	lexMarkup("a <link-1>b</link-1> c",
+		`"a "`, "<link-1>", `"b"`, "</link-1>", `" c"`)
+	// < is literal unless it starts a tag.
+	lexMarkup("1 < 2 <",
+		`"1 < 2 <"`)
+	lexMarkup("I <3 you",
+		`"I <3 you"`)
+	lexMarkup("a<b and c</d",
+		`"a<b and c</d"`)
+	lexMarkup("x <b c> y </ b>",
+		`"x <b c> y </ b>"`)
+	// <name/> is the same as <name></name>.
+	lexMarkup("a<br/>b",
+		`"a"`, "<br>", `""`, "</br>", `"b"`)
+	lexMarkup("a</br/>",
+		`"a</br/>"`)
+	// < can be quoted.
+	lexMarkup("'<'b>",
+		`"<b>"`)
+
+	// Without markup, tags are text.
+	lexText(t,
+		"<b>bold</b>",
+		`"<b>bold</b>"`)
+}
+
 func TestLexTextError(t *testing.T) {
 	lexTextError := func(input string, expected error) {
 		l := newLexer(input)
@@ -151,3 +210,27 @@ func TestLexArg(t *testing.T) {
 		"{ arg, plural, offset:1 =0 {} =1 {} one{} other{} }",
 		"{", "arg", ",", "plural", ",", "offset", ":", "1", "=", "0", "{", "}", "=", "1", "{", "}", "one", "{", "}", "other", "{", "}", "}")
 }
+
+func TestLexArgStyle(t *testing.T) {
+	lexArgStyle := func(input string, tokens ...string) {
+		l := newLexer(input)
+		err := l.LexArgStyle()
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		}
+		var actual []string
+		for _, token := range l.Output {
+			actual = append(actual, token.String())
+		}
+		if !reflect.DeepEqual(actual, tokens) {
+			t.Errorf("expected: %v\n", tokens)
+			t.Errorf("actual: %v\n", actual)
+		}
+	}
+
+	lexArgStyle(" USD }", `"USD"`, "}")
+	lexArgStyle(" ::currency/EUR unit-width-narrow}rest", `"::currency/EUR unit-width-narrow"`, "}")
+	lexArgStyle("a {b {c}} d}", `"a {b {c}} d"`, "}")
+	lexArgStyle("a '}' b}", `"a '}' b"`, "}")
+	lexArgStyle("a", `"a"`, "<EOF>")
+}
@@ -0,0 +1,77 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+type listStyle struct {
+	Type  icu4c.ListType
+	Width icu4c.ListWidth
+}
+
+// parseListStyle parses the style of a list argument.
+// The default is conjunction in the wide width.
+func parseListStyle(style string) (out listStyle, err error) {
+	out = listStyle{
+		Type:  icu4c.ListTypeConjunction,
+		Width: icu4c.ListWidthWide,
+	}
+	for _, word := range strings.Fields(style) {
+		switch word {
+		case "conjunction":
+			out.Type = icu4c.ListTypeConjunction
+		case "disjunction":
+			out.Type = icu4c.ListTypeDisjunction
+		case "unit":
+			out.Type = icu4c.ListTypeUnit
+		case "short":
+			out.Width = icu4c.ListWidthShort
+		case "narrow":
+			out.Width = icu4c.ListWidthNarrow
+		default:
+			err = fmt.Errorf("unexpected style: %v", word)
+			return
+		}
+	}
+	return
+}
+
+// listItems formats the elements of value like `{Argument}`.
+// ok is false if value is neither []string nor []interface{}.
+func listItems(tag language.Tag, value interface{}) (items []string, ok bool, err error) {
+	switch v := value.(type) {
+	case []string:
+		return v, true, nil
+	case []interface{}:
+		for _, item := range v {
+			var s string
+			s, err = formatValue(item)
+			if err != nil {
+				err = fmt.Errorf("unsupported list item type: %T", item)
+				return
+			}
+			if isNumber(item) {
+				s, err = localizeDigits(tag, s)
+				if err != nil {
+					return
+				}
+			}
+			items = append(items, s)
+		}
+		return items, true, nil
+	}
+	return nil, false, nil
+}
+
+func formatList(tag language.Tag, style string, items []string) (out string, err error) {
+	s, err := parseListStyle(style)
+	if err != nil {
+		return
+	}
+	return icu4c.FormatList(tag, s.Type, s.Width, items)
+}
@@ -0,0 +1,58 @@
+package messageformat
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+func TestParseListStyle(t *testing.T) {
+	test := func(style string, expected listStyle) {
+		actual, err := parseListStyle(style)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%q: %v != %v\n", style, actual, expected)
+		}
+	}
+
+	test("", listStyle{Type: icu4c.ListTypeConjunction, Width: icu4c.ListWidthWide})
+	test("disjunction", listStyle{Type: icu4c.ListTypeDisjunction, Width: icu4c.ListWidthWide})
+	test("unit narrow", listStyle{Type: icu4c.ListTypeUnit, Width: icu4c.ListWidthNarrow})
+	test("short", listStyle{Type: icu4c.ListTypeConjunction, Width: icu4c.ListWidthShort})
+
+	_, err := parseListStyle("conjunction wide")
+	if err == nil || err.Error() != "unexpected style: wide" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestListItems(t *testing.T) {
+	test := func(value interface{}, expected []string) {
+		actual, ok, err := listItems(language.English, value)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if !ok {
+			t.Errorf("%#v: expected ok\n", value)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%#v: %#v != %#v\n", value, actual, expected)
+		}
+	}
+
+	test([]string{"a", "b"}, []string{"a", "b"})
+	test([]interface{}{"a", 1, 2.5, int64(-3)}, []string{"a", "1", "2.5", "-3"})
+	test([]interface{}{}, nil)
+
+	_, ok, err := listItems(language.English, "a")
+	if ok || err != nil {
+		t.Errorf("unexpected: %v %v\n", ok, err)
+	}
+
+	_, _, err = listItems(language.English, []interface{}{"a", struct{}{}})
+	if err == nil || err.Error() != "unsupported list item type: struct {}" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
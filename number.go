@@ -0,0 +1,197 @@
+package messageformat
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+// Number is a number displayed with a range of fraction digits.
+// Plural selection and `#` see the number as displayed,
+// so Number{Value: 1, MinimumFractionDigits: 1} is "1.0"
+// and selects "other" instead of "one" in English.
+//
+// Value can be any supported numeric type.
+// It is rounded half to even to MaximumFractionDigits digits
+// and padded with zeros to MinimumFractionDigits digits.
+// MaximumFractionDigits less than MinimumFractionDigits is taken as MinimumFractionDigits,
+// so the zero value of both displays the integer.
+type Number struct {
+	Value                 interface{}
+	MinimumFractionDigits int
+	MaximumFractionDigits int
+}
+
+// decimalString returns n as displayed.
+func (n Number) decimalString() (out string, err error) {
+	if n.MinimumFractionDigits < 0 || n.MaximumFractionDigits < 0 {
+		err = fmt.Errorf("negative fraction digits: %v %v", n.MinimumFractionDigits, n.MaximumFractionDigits)
+		return
+	}
+	s, err := plainDecimalString(n.Value)
+	if err != nil {
+		return
+	}
+	max := n.MaximumFractionDigits
+	if max < n.MinimumFractionDigits {
+		max = n.MinimumFractionDigits
+	}
+	return roundDecimal(s, n.MinimumFractionDigits, max), nil
+}
+
+// plainDecimalString returns value in plain decimal notation.
+func plainDecimalString(value interface{}) (out string, err error) {
+	switch v := value.(type) {
+	case float32:
+		out = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		out = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		var r *big.Rat
+		var fractionDigits int
+		r, fractionDigits, err = parseDecimal(v)
+		if err != nil {
+			return
+		}
+		out = r.FloatString(fractionDigits)
+	case Number:
+		err = fmt.Errorf("unsupported nested Number")
+	default:
+		var s string
+		s, err = formatValue(value)
+		if err != nil {
+			return
+		}
+		out, err = plainDecimalString(s)
+	}
+	return
+}
+
+// roundDecimal rounds the plain decimal s half to even to max fraction digits,
+// and then removes trailing zeros in the fraction beyond min fraction digits.
+func roundDecimal(s string, min int, max int) string {
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	integral := s
+	fraction := ""
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		integral = s[:idx]
+		fraction = s[idx+1:]
+	}
+
+	if len(fraction) > max {
+		kept := fraction[:max]
+		dropped := fraction[max:]
+		digits := integral + kept
+		roundUp := false
+		switch {
+		case dropped[0] > '5':
+			roundUp = true
+		case dropped[0] == '5':
+			if strings.TrimRight(dropped[1:], "0") != "" {
+				roundUp = true
+			} else {
+				// Round half to even.
+				roundUp = (digits[len(digits)-1]-'0')%2 == 1
+			}
+		}
+		if roundUp {
+			i, _ := new(big.Int).SetString(digits, 10)
+			digits = i.Add(i, big.NewInt(1)).String()
+			if len(digits) < max+1 {
+				digits = strings.Repeat("0", max+1-len(digits)) + digits
+			}
+		}
+		integral = digits[:len(digits)-max]
+		fraction = digits[len(digits)-max:]
+	}
+
+	for len(fraction) > min && strings.HasSuffix(fraction, "0") {
+		fraction = fraction[:len(fraction)-1]
+	}
+	for len(fraction) < min {
+		fraction += "0"
+	}
+
+	out := integral
+	if fraction != "" {
+		out += "." + fraction
+	}
+	if negative && strings.Trim(out, "0.") != "" {
+		out = "-" + out
+	}
+	return out
+}
+
+// numberSkeleton resolves the style of a number argument to a number skeleton.
+// See https://unicode-org.github.io/icu/userguide/format_parse/numbers/skeletons.html
+func numberSkeleton(style string) (string, error) {
+	switch style {
+	case "":
+		return "", nil
+	case "integer":
+		return "precision-integer", nil
+	case "percent":
+		return "percent scale/100", nil
+	}
+	if strings.HasPrefix(style, "::") && len(style) > 2 {
+		return strings.TrimSpace(style[2:]), nil
+	}
+	return "", fmt.Errorf("unexpected style: %v", style)
+}
+
+// numberDecimal returns value in plain decimal notation.
+// If value is a Number, it is rounded as displayed,
+// and precision is the precision stem displaying its fraction digits.
+func numberDecimal(value interface{}) (decimal string, precision string, err error) {
+	n, ok := value.(Number)
+	if !ok {
+		decimal, err = plainDecimalString(value)
+		return
+	}
+
+	decimal, err = n.decimalString()
+	if err != nil {
+		return
+	}
+	max := n.MaximumFractionDigits
+	if max < n.MinimumFractionDigits {
+		max = n.MinimumFractionDigits
+	}
+	if max == 0 {
+		precision = "precision-integer"
+	} else {
+		precision = "." + strings.Repeat("0", n.MinimumFractionDigits) + strings.Repeat("#", max-n.MinimumFractionDigits)
+	}
+	return
+}
+
+// hasPrecisionStem reports whether the number skeleton has a precision stem,
+// such as ".00", "@@@" or "precision-integer".
+func hasPrecisionStem(skeleton string) bool {
+	for _, token := range strings.Fields(skeleton) {
+		if strings.HasPrefix(token, ".") || strings.HasPrefix(token, "@") || strings.HasPrefix(token, "precision-") {
+			return true
+		}
+	}
+	return false
+}
+
+// formatNumber formats decimal with the style of a number argument.
+// precision is used if the style has no precision stem.
+func formatNumber(tag language.Tag, style string, decimal string, precision string) (out string, err error) {
+	skeleton, err := numberSkeleton(style)
+	if err != nil {
+		return
+	}
+	if precision != "" && !hasPrecisionStem(skeleton) {
+		skeleton = strings.TrimSpace(skeleton + " " + precision)
+	}
+	return icu4c.FormatNumber(tag, skeleton, decimal)
+}
@@ -0,0 +1,159 @@
+package messageformat
+
+import (
+	"math/big"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestRoundDecimal(t *testing.T) {
+	test := func(s string, min int, max int, expected string) {
+		actual := roundDecimal(s, min, max)
+		if actual != expected {
+			t.Errorf("%v %v %v: %q != %q\n", s, min, max, actual, expected)
+		}
+	}
+
+	test("1", 0, 0, "1")
+	test("1", 1, 1, "1.0")
+	test("1", 2, 3, "1.00")
+	test("1.5", 0, 3, "1.5")
+	test("1.500", 0, 3, "1.5")
+	test("1.500", 2, 3, "1.50")
+
+	// Round half to even.
+	test("0.5", 0, 0, "0")
+	test("1.5", 0, 0, "2")
+	test("2.5", 0, 0, "2")
+	test("2.51", 0, 0, "3")
+	test("1.125", 2, 2, "1.12")
+	test("1.135", 2, 2, "1.14")
+	test("1.1251", 2, 2, "1.13")
+	test("9.995", 2, 2, "10.00")
+	test("99.96", 0, 1, "100")
+	test("0.05", 1, 1, "0.0")
+	test("0.06", 1, 1, "0.1")
+
+	// Negative numbers.
+	test("-1.25", 1, 1, "-1.2")
+	test("-1.26", 1, 1, "-1.3")
+	test("-0.001", 2, 2, "0.00")
+}
+
+func TestNumber(t *testing.T) {
+	test := func(n Number, expected string) {
+		actual, err := n.decimalString()
+		if err != nil {
+			t.Errorf("%#v: err: %v\n", n, err)
+		} else if actual != expected {
+			t.Errorf("%#v: %q != %q\n", n, actual, expected)
+		}
+	}
+
+	test(Number{Value: 1}, "1")
+	test(Number{Value: 1, MinimumFractionDigits: 1}, "1.0")
+	test(Number{Value: 1.0, MinimumFractionDigits: 1}, "1.0")
+	test(Number{Value: 1.234, MaximumFractionDigits: 2}, "1.23")
+	test(Number{Value: 1.2, MinimumFractionDigits: 2, MaximumFractionDigits: 3}, "1.20")
+	test(Number{Value: "1.5000", MaximumFractionDigits: 3}, "1.5")
+	test(Number{Value: big.NewRat(1, 8), MaximumFractionDigits: 2}, "0.12")
+	test(Number{Value: testUserID(3), MinimumFractionDigits: 1}, "3.0")
+
+	_, err := Number{Value: "abc"}.decimalString()
+	if err == nil {
+		t.Errorf("expected error\n")
+	}
+}
+
+func TestNumberPlural(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	pattern := "{N, plural, one {# file} other {# files}}"
+	test(pattern, "1 file", map[string]interface{}{
+		"N": Number{Value: 1},
+	})
+	test(pattern, "1.0 files", map[string]interface{}{
+		"N": Number{Value: 1, MinimumFractionDigits: 1},
+	})
+	test(pattern, "1 file", map[string]interface{}{
+		"N": Number{Value: 1.004, MaximumFractionDigits: 2},
+	})
+	test("{N, plural, offset:1 one {# file} other {# files}}", "1.0 files", map[string]interface{}{
+		"N": Number{Value: 2, MinimumFractionDigits: 1},
+	})
+	test("{N, plural, offset:1 =2 {two} one {# file} other {# files}}", "two", map[string]interface{}{
+		"N": Number{Value: 2.001, MaximumFractionDigits: 2},
+	})
+}
+
+func TestNumberSkeleton(t *testing.T) {
+	test := func(style string, expected string) {
+		actual, err := numberSkeleton(style)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%q: %q != %q\n", style, actual, expected)
+		}
+	}
+
+	test("", "")
+	test("integer", "precision-integer")
+	test("percent", "percent scale/100")
+	test("::unit/kilometer unit-width-short", "unit/kilometer unit-width-short")
+	test(":: currency/EUR", "currency/EUR")
+
+	for _, style := range []string{"::", "#,##0.00", "currency"} {
+		_, err := numberSkeleton(style)
+		if err == nil || err.Error() != "unexpected style: "+style {
+			t.Errorf("%q: unexpected error: %v\n", style, err)
+		}
+	}
+}
+
+func TestNumberDecimal(t *testing.T) {
+	test := func(value interface{}, expectedDecimal string, expectedPrecision string) {
+		decimal, precision, err := numberDecimal(value)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if decimal != expectedDecimal || precision != expectedPrecision {
+			t.Errorf("%#v: %q %q != %q %q\n", value, decimal, precision, expectedDecimal, expectedPrecision)
+		}
+	}
+
+	test(1234.5, "1234.5", "")
+	test("-0.50", "-0.50", "")
+	test(big.NewInt(42), "42", "")
+	test(Number{Value: 1, MinimumFractionDigits: 2}, "1.00", ".00")
+	test(Number{Value: 1.256, MinimumFractionDigits: 1, MaximumFractionDigits: 2}, "1.26", ".0#")
+	test(Number{Value: 1.5}, "2", "precision-integer")
+
+	_, _, err := numberDecimal("abc")
+	if err == nil {
+		t.Errorf("expected error\n")
+	}
+}
+
+func TestHasPrecisionStem(t *testing.T) {
+	test := func(skeleton string, expected bool) {
+		actual := hasPrecisionStem(skeleton)
+		if actual != expected {
+			t.Errorf("%q: %v != %v\n", skeleton, actual, expected)
+		}
+	}
+
+	test("", false)
+	test("unit/kilometer", false)
+	test("unit/kilometer .00", true)
+	test("@@@ group-off", true)
+	test("precision-integer", true)
+	test("percent scale/100", false)
+}
@@ -0,0 +1,44 @@
+package messageformat
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+// hasNumberingSystem reports whether tag has the -u-nu- extension,
+// such as en-u-nu-arab.
+func hasNumberingSystem(tag language.Tag) bool {
+	return tag.TypeForKey("nu") != ""
+}
+
+// fractionSkeleton is the number skeleton displaying exactly v fraction digits.
+func fractionSkeleton(v int) string {
+	if v == 0 {
+		return "precision-integer"
+	}
+	return "." + strings.Repeat("0", v)
+}
+
+// localizeDigits displays the decimal string s in the numbering system of
+// the -u-nu- extension of tag with icu4c.
+// Grouping is off and the fraction digits are kept,
+// so only the digits, the sign and the decimal separator change.
+// Without the extension, s is returned as is.
+// Without icu4c, s is also returned as is if the numbering system is latn.
+func localizeDigits(tag language.Tag, s string) (out string, err error) {
+	if !hasNumberingSystem(tag) {
+		return s, nil
+	}
+	v := 0
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		v = len(s) - idx - 1
+	}
+	out, err = icu4c.FormatNumber(tag, "group-off "+fractionSkeleton(v), s)
+	if err == icu4c.ErrUnsupported && tag.TypeForKey("nu") == "latn" {
+		return s, nil
+	}
+	return
+}
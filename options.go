@@ -0,0 +1,89 @@
+package messageformat
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Options customizes parsing and formatting.
+// The zero value is the behavior of the package-level functions.
+type Options struct {
+	// Markup enables markup tags in the pattern, such as `<b>bold</b>`.
+	// Tags must be properly nested.
+	// A literal < can still be written with quoting, such as `'<'b`.
+	Markup bool
+	// MarkupFuncs maps tag names to the functions rendering them
+	// in FormatNamed and FormatPositional.
+	// The content of a tag without a function is output as is.
+	MarkupFuncs map[string]MarkupFunc
+	// MarkupTemplates maps tag names to the templates invoked around
+	// their content in FormatTemplateParseTree.
+	// The content of a tag without templates is output as is.
+	MarkupTemplates map[string]MarkupTemplate
+	// BidiIsolation isolates the arguments from the surrounding text,
+	// so that for example an English name does not reorder the punctuation
+	// of a Hebrew message.
+	// Every argument is isolated, including # and formatted arguments such as dates.
+	// An argument is wrapped in U+2068 FIRST STRONG ISOLATE
+	// and U+2069 POP DIRECTIONAL ISOLATE when the direction of its first strong character
+	// differs from the direction of the language.
+	// FormatTemplateParseTree does the same at runtime,
+	// so the output is plain text valid in any context of the template.
+	BidiIsolation bool
+	// Calendar is the BCP 47 calendar type of date, time and datetime arguments,
+	// such as "japanese", "buddhist" or "islamic-umalqura".
+	// It overrides the -u-ca- extension of the language tag.
+	// If it is empty, the -u-ca- extension of the language tag is used,
+	// or else the default calendar of the language.
+	Calendar string
+	// Now returns the current time, which relativetime arguments of time.Time are relative to.
+	// If it is nil, time.Now is used.
+	// FormatTemplateParseTree does not use it,
+	// the output template uses time.Now when it is executed.
+	Now func() time.Time
+	// RewriteChoice rewrites choice arguments to plural arguments with explicit values
+	// when they are equivalent for non-negative integers, such as
+	// `{0, choice, 0#no files|1#one file|1<{0} files}` to
+	// `{0, plural, =0 {no files} =1 {one file} other {{0} files}}`.
+	// Other choice arguments are kept.
+	RewriteChoice bool
+}
+
+func (o Options) now() time.Time {
+	if o.Now != nil {
+		return o.Now()
+	}
+	return time.Now()
+}
+
+// languageTag applies the options to tag.
+func (o Options) languageTag(tag language.Tag) (out language.Tag, err error) {
+	out = tag
+	if o.Calendar != "" {
+		var ext language.Extension
+		ext, err = language.ParseExtension("u-ca-" + o.Calendar)
+		if err != nil {
+			err = fmt.Errorf("invalid calendar: %v", o.Calendar)
+			return
+		}
+		out, err = language.Compose(tag, ext)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MarkupFunc renders a markup tag with its formatted content.
+type MarkupFunc func(content string) string
+
+// MarkupTemplate is the names of the templates invoked around the content
+// of a markup tag. The templates are invoked with the data of the message.
+// Since the content stays in the parse tree, html/template still escapes
+// the arguments inside.
+type MarkupTemplate struct {
+	Open  string
+	Close string
+}
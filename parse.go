@@ -3,6 +3,8 @@ package messageformat
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Argument is either named argument or positional argument.
@@ -54,6 +56,86 @@ type DatetimeArgNode struct {
 
 func (_ DatetimeArgNode) messageFormatNode() {}
 
+// DateIntervalArgNode is `{Argument, dateinterval, short | medium | long | full | ::Skeleton}`.
+type DateIntervalArgNode struct {
+	Arg Argument
+	// Style is the verbatim style text, such as "medium" or "::yMMMd".
+	Style string
+}
+
+func (_ DateIntervalArgNode) messageFormatNode() {}
+
+// RelativeTimeArgNode is `{Argument, relativetime [, Style]}`
+// where Style is a combination of long | short | narrow and numeric | auto.
+type RelativeTimeArgNode struct {
+	Arg Argument
+	// Style is the verbatim style text, such as "short auto".
+	Style string
+}
+
+func (_ RelativeTimeArgNode) messageFormatNode() {}
+
+// ListArgNode is `{Argument, list [, Style]}`
+// where Style is a combination of conjunction | disjunction | unit and short | narrow.
+type ListArgNode struct {
+	Arg Argument
+	// Style is the verbatim style text, such as "disjunction short".
+	Style string
+}
+
+func (_ ListArgNode) messageFormatNode() {}
+
+// NumberArgNode is `{Argument, number [, integer | percent | ::Skeleton]}`.
+type NumberArgNode struct {
+	Arg Argument
+	// Style is the verbatim style text, such as "::unit/kilometer unit-width-short".
+	Style string
+}
+
+func (_ NumberArgNode) messageFormatNode() {}
+
+// CurrencyArgNode is `{Argument, currency [, symbol | narrow | code | name]}`.
+type CurrencyArgNode struct {
+	Arg   Argument
+	Style string
+}
+
+func (_ CurrencyArgNode) messageFormatNode() {}
+
+// DurationArgNode is `{Argument, duration [, Style]}`
+// where Style is a combination of long | short | narrow | digital,
+// largest/Unit, smallest/Unit and %RuleSet.
+type DurationArgNode struct {
+	Arg Argument
+	// Style is the verbatim style text, such as "long smallest/minute".
+	Style string
+}
+
+func (_ DurationArgNode) messageFormatNode() {}
+
+// RuleBasedNumberArgNode is `{Argument, spellout | ordinal [, %RuleSet]}`.
+type RuleBasedNumberArgNode struct {
+	Arg Argument
+	// spellout or ordinal
+	Type string
+	// Style is the rule set, such as "%spellout-ordinal".
+	// It is empty for the default rule set.
+	Style string
+}
+
+func (_ RuleBasedNumberArgNode) messageFormatNode() {}
+
+// CustomArgNode is `{Argument, Type [, Style]}`
+// where Type is registered with RegisterArgumentType.
+type CustomArgNode struct {
+	Arg  Argument
+	Type string
+	// Style is the verbatim style text.
+	Style string
+}
+
+func (_ CustomArgNode) messageFormatNode() {}
+
 // SelectClause is `Keyword {message}`.
 type SelectClause struct {
 	Keyword string
@@ -87,22 +169,97 @@ type PluralArgNode struct {
 
 func (_ PluralArgNode) messageFormatNode() {}
 
+// ChoiceClause is `Limit (# | < | ≤) message`.
+// The clause is selected by numbers from Limit up to the limit of the next clause.
+type ChoiceClause struct {
+	Limit float64
+	// Strict is true for <, where Limit itself is excluded.
+	// # and ≤ include Limit.
+	Strict bool
+	Nodes  []Node
+}
+
+// ChoiceArgNode is `{Argument, choice, ChoiceClause ( '|' ChoiceClause)*}`.
+// It is the legacy ChoiceFormat of Java, such as
+// `{0, choice, 0#no files|1#one file|1<{0} files}`.
+// Numbers below the first limit select the first clause.
+type ChoiceArgNode struct {
+	Arg     Argument
+	Clauses []ChoiceClause
+}
+
+func (_ ChoiceArgNode) messageFormatNode() {}
+
 // PoundNode is `#`.
 type PoundNode struct{}
 
 func (_ PoundNode) messageFormatNode() {}
 
+// MarkupNode is `<Name>message</Name>`.
+// It is only recognized when Options.Markup is true.
+type MarkupNode struct {
+	Name  string
+	Nodes []Node
+}
+
+func (_ MarkupNode) messageFormatNode() {}
+
+var builtinArgumentTypes = []string{
+	"plural",
+	"select",
+	"selectordinal",
+	"date",
+	"time",
+	"datetime",
+	"dateinterval",
+	"relativetime",
+	"list",
+	"number",
+	"currency",
+	"duration",
+	"spellout",
+	"ordinal",
+	"choice",
+}
+
+func isBuiltinArgumentType(typ string) bool {
+	for _, t := range builtinArgumentTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
 // Parse parses the pattern s into message.
 func Parse(s string) ([]Node, error) {
-	p := parser{lexer: newLexer(s)}
+	return Options{}.Parse(s)
+}
+
+// Parse parses the pattern s into message.
+func (o Options) Parse(s string) ([]Node, error) {
+	nodes, err := newParser(s, o.Markup).parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if o.RewriteChoice {
+		nodes = rewriteChoice(nodes)
+	}
+	return nodes, nil
+}
+
+func newParser(s string, markup bool) *parser {
+	p := &parser{lexer: newLexer(s)}
 	p.lexer.isInPluralStyle = p.isInPluralStyle
-	return p.parse(s)
+	p.lexer.markup = markup
+	return p
 }
 
 type parser struct {
 	lexer      *lexer
 	tokens     []Token
 	poundStack []bool
+	tagStack   []string
 }
 
 func (p *parser) pushPoundStack(pound bool) {
@@ -160,6 +317,21 @@ func (p *parser) expect(types ...TokenType) (*Token, error) {
 	return nil, fmt.Errorf("unexpected token: %v", token)
 }
 
+// expectArgStyle expects the style text of an argument.
+// It must be called right after the comma preceding the style text.
+func (p *parser) expectArgStyle() (*Token, error) {
+	if len(p.tokens) > 0 {
+		return nil, fmt.Errorf("unexpected token: %v", p.tokens[0])
+	}
+	err := p.lexer.LexArgStyle()
+	if err != nil {
+		return nil, err
+	}
+	p.tokens = p.lexer.Output
+	p.lexer.Output = nil
+	return p.expect(TokenTypeText)
+}
+
 func (p *parser) expectWord(words ...string) (*Token, error) {
 	word, err := p.expect(TokenTypeWord)
 	if err != nil {
@@ -230,14 +402,22 @@ func (p *parser) parseMessageText0() (Node, error) {
 }
 
 func (p *parser) parseArgMessageText(endToken TokenType) ([]Node, error) {
-	lbraceOrEnd, err := p.expect(TokenTypeLBrace, endToken)
+	lbraceOrEnd, err := p.expect(TokenTypeLBrace, TokenTypeTagOpen, endToken)
 	if err != nil {
 		return nil, err
 	}
 	if lbraceOrEnd.Type == endToken {
+		if endToken == TokenTypeTagClose && lbraceOrEnd.Value != p.tagStack[len(p.tagStack)-1] {
+			return nil, fmt.Errorf("unexpected token: %v", lbraceOrEnd)
+		}
 		return nil, err
 	}
-	argNode, err := p.parseArg()
+	var argNode Node
+	if lbraceOrEnd.Type == TokenTypeTagOpen {
+		argNode, err = p.parseMarkup(lbraceOrEnd.Value)
+	} else {
+		argNode, err = p.parseArg()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +435,20 @@ func (p *parser) parseArgMessageText(endToken TokenType) ([]Node, error) {
 	return out, nil
 }
 
+func (p *parser) parseMarkup(name string) (Node, error) {
+	p.tagStack = append(p.tagStack, name)
+	defer func() {
+		p.tagStack = p.tagStack[0 : len(p.tagStack)-1]
+	}()
+
+	// # inside markup means the same thing as outside.
+	nodes, err := p.parseMessage(TokenTypeTagClose, p.isInPluralStyle())
+	if err != nil {
+		return nil, err
+	}
+	return MarkupNode{Name: name, Nodes: nodes}, nil
+}
+
 func (p *parser) parseArg() (Node, error) {
 	argNameOrNumber, err := p.expect(TokenTypeWord, TokenTypeNumber)
 	if err != nil {
@@ -281,11 +475,82 @@ func (p *parser) parseArg() (Node, error) {
 		return NoneArgNode{Arg: arg}, nil
 	}
 
-	argType, err := p.expectWord("plural", "select", "selectordinal", "date", "time", "datetime")
+	argType, err := p.expect(TokenTypeWord)
 	if err != nil {
 		return nil, err
 	}
 
+	if !isBuiltinArgumentType(argType.Value) {
+		if _, ok := lookupArgumentFormatter(argType.Value); !ok {
+			return nil, fmt.Errorf("unexpected token: %v", argType)
+		}
+		return p.parseCustomArg(arg, argType.Value)
+	}
+
+	// The style of these types is optional.
+	switch argType.Value {
+	case "relativetime":
+		style, err := p.parseOptionalArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = parseRelativeTimeStyle(style)
+		if err != nil {
+			return nil, err
+		}
+		return RelativeTimeArgNode{Arg: arg, Style: style}, nil
+	case "list":
+		style, err := p.parseOptionalArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = parseListStyle(style)
+		if err != nil {
+			return nil, err
+		}
+		return ListArgNode{Arg: arg, Style: style}, nil
+	case "number":
+		style, err := p.parseOptionalArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = numberSkeleton(style)
+		if err != nil {
+			return nil, err
+		}
+		return NumberArgNode{Arg: arg, Style: style}, nil
+	case "currency":
+		style, err := p.parseOptionalArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = currencyUnitWidth(style)
+		if err != nil {
+			return nil, err
+		}
+		return CurrencyArgNode{Arg: arg, Style: style}, nil
+	case "duration":
+		style, err := p.parseOptionalArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = parseDurationStyle(style)
+		if err != nil {
+			return nil, err
+		}
+		return DurationArgNode{Arg: arg, Style: style}, nil
+	case "spellout", "ordinal":
+		style, err := p.parseOptionalArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = parseRuleSet(style)
+		if err != nil {
+			return nil, err
+		}
+		return RuleBasedNumberArgNode{Arg: arg, Type: argType.Value, Style: style}, nil
+	}
+
 	_, err = p.expect(TokenTypeComma)
 	if err != nil {
 		return nil, err
@@ -340,11 +605,69 @@ func (p *parser) parseArg() (Node, error) {
 			return nil, err
 		}
 		return DatetimeArgNode{Arg: arg, Style: style.Value}, nil
+	case "dateinterval":
+		style, err := p.expectArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		_, err = dateIntervalSkeleton(style.Value)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(TokenTypeRBrace)
+		if err != nil {
+			return nil, err
+		}
+		return DateIntervalArgNode{Arg: arg, Style: style.Value}, nil
+	case "choice":
+		style, err := p.expectArgStyle()
+		if err != nil {
+			return nil, err
+		}
+		clauses, err := p.parseChoiceStyle(style.Value)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(TokenTypeRBrace)
+		if err != nil {
+			return nil, err
+		}
+		return ChoiceArgNode{Arg: arg, Clauses: clauses}, nil
 	}
 
 	panic("unreachable")
 }
 
+func (p *parser) parseCustomArg(arg Argument, typ string) (Node, error) {
+	style, err := p.parseOptionalArgStyle()
+	if err != nil {
+		return nil, err
+	}
+	return CustomArgNode{Arg: arg, Type: typ, Style: style}, nil
+}
+
+// parseOptionalArgStyle parses `}` or `, Style}` after the argument type.
+// The style is empty if it is absent.
+func (p *parser) parseOptionalArgStyle() (string, error) {
+	rbraceOrComma, err := p.expect(TokenTypeRBrace, TokenTypeComma)
+	if err != nil {
+		return "", err
+	}
+	if rbraceOrComma.Type == TokenTypeRBrace {
+		return "", nil
+	}
+
+	style, err := p.expectArgStyle()
+	if err != nil {
+		return "", err
+	}
+	_, err = p.expect(TokenTypeRBrace)
+	if err != nil {
+		return "", err
+	}
+	return style.Value, nil
+}
+
 func (p *parser) parsePluralStyle() (offset int, clauses []PluralClause, err error) {
 	for {
 		var token *Token
@@ -416,6 +739,44 @@ func (p *parser) parsePluralStyle() (offset int, clauses []PluralClause, err err
 	}
 }
 
+// parseChoiceStyle parses the clauses of a choice argument.
+// The messages of the clauses are parsed as patterns on their own,
+// so # in them is literal.
+func (p *parser) parseChoiceStyle(style string) ([]ChoiceClause, error) {
+	if style == "" {
+		return nil, fmt.Errorf("no choice clauses")
+	}
+
+	var clauses []ChoiceClause
+	for _, s := range splitChoiceStyle(style) {
+		i := strings.IndexAny(s, "#<≤")
+		if i < 0 {
+			return nil, fmt.Errorf("unexpected choice clause: %v", s)
+		}
+		limit, err := parseChoiceLimit(strings.TrimSpace(s[:i]))
+		if err != nil {
+			return nil, err
+		}
+		if len(clauses) > 0 && limit < clauses[len(clauses)-1].Limit {
+			return nil, fmt.Errorf("choice limits must be in ascending order: %v", style)
+		}
+		separator, size := utf8.DecodeRuneInString(s[i:])
+
+		message := s[i+size:]
+		nodes, err := newParser(message, p.lexer.markup).parse(message)
+		if err != nil {
+			return nil, err
+		}
+
+		clauses = append(clauses, ChoiceClause{
+			Limit:  limit,
+			Strict: separator == '<',
+			Nodes:  nodes,
+		})
+	}
+	return clauses, nil
+}
+
 func (p *parser) parseSelectStyle() ([]SelectClause, error) {
 	var clauses []SelectClause
 	for {
@@ -424,3 +424,278 @@ func TestParseDatetime(t *testing.T) {
 		TextNode{""},
 	})
 }
+
+func TestParseDateInterval(t *testing.T) {
+	parse(t, "{r, dateinterval, medium} {r, dateinterval, ::yMMMd}", []Node{
+		TextNode{},
+		DateIntervalArgNode{Arg: Argument{Name: "r"}, Style: "medium"},
+		TextNode{" "},
+		DateIntervalArgNode{Arg: Argument{Name: "r"}, Style: "::yMMMd"},
+		TextNode{},
+	})
+
+	test := func(s string, expected string) {
+		_, err := Parse(s)
+		if err == nil {
+			t.Errorf("%v: expected error\n", s)
+		} else if err.Error() != expected {
+			t.Errorf("%v: %v != %v\n", s, err.Error(), expected)
+		}
+	}
+
+	test("{r, dateinterval, yMMMd}", "unexpected style: yMMMd")
+	test("{r, dateinterval, ::}", "unexpected style: ::")
+	test("{r, dateinterval}", "unexpected token: }")
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	parse(t, "{t, relativetime} {t, relativetime, short auto}", []Node{
+		TextNode{},
+		RelativeTimeArgNode{Arg: Argument{Name: "t"}},
+		TextNode{" "},
+		RelativeTimeArgNode{Arg: Argument{Name: "t"}, Style: "short auto"},
+		TextNode{},
+	})
+
+	_, err := Parse("{t, relativetime, always}")
+	if err == nil || err.Error() != "unexpected style: always" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	parse(t, "{names, list} {names, list, disjunction short}", []Node{
+		TextNode{},
+		ListArgNode{Arg: Argument{Name: "names"}},
+		TextNode{" "},
+		ListArgNode{Arg: Argument{Name: "names"}, Style: "disjunction short"},
+		TextNode{},
+	})
+
+	_, err := Parse("{names, list, and}")
+	if err == nil || err.Error() != "unexpected style: and" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	parse(t, "{n, number} {n, number, integer} {n, number, ::unit/kilometer unit-width-short}", []Node{
+		TextNode{},
+		NumberArgNode{Arg: Argument{Name: "n"}},
+		TextNode{" "},
+		NumberArgNode{Arg: Argument{Name: "n"}, Style: "integer"},
+		TextNode{" "},
+		NumberArgNode{Arg: Argument{Name: "n"}, Style: "::unit/kilometer unit-width-short"},
+		TextNode{},
+	})
+
+	_, err := Parse("{n, number, #,##0}")
+	if err == nil || err.Error() != "unexpected style: #,##0" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	parse(t, "{p, currency} {p, currency, name}", []Node{
+		TextNode{},
+		CurrencyArgNode{Arg: Argument{Name: "p"}},
+		TextNode{" "},
+		CurrencyArgNode{Arg: Argument{Name: "p"}, Style: "name"},
+		TextNode{},
+	})
+
+	_, err := Parse("{p, currency, EUR}")
+	if err == nil || err.Error() != "unexpected style: EUR" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	parse(t, "{d, duration} {d, duration, digital smallest/minute}", []Node{
+		TextNode{},
+		DurationArgNode{Arg: Argument{Name: "d"}},
+		TextNode{" "},
+		DurationArgNode{Arg: Argument{Name: "d"}, Style: "digital smallest/minute"},
+		TextNode{},
+	})
+
+	_, err := Parse("{d, duration, largest/fortnight}")
+	if err == nil || err.Error() != "unexpected style: largest/fortnight" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestParseRuleBasedNumber(t *testing.T) {
+	parse(t, "{n, spellout} {n, ordinal} {n, spellout, %spellout-ordinal}", []Node{
+		TextNode{},
+		RuleBasedNumberArgNode{Arg: Argument{Name: "n"}, Type: "spellout"},
+		TextNode{" "},
+		RuleBasedNumberArgNode{Arg: Argument{Name: "n"}, Type: "ordinal"},
+		TextNode{" "},
+		RuleBasedNumberArgNode{Arg: Argument{Name: "n"}, Type: "spellout", Style: "%spellout-ordinal"},
+		TextNode{},
+	})
+
+	_, err := Parse("{n, ordinal, long}")
+	if err == nil || err.Error() != "unexpected style: long" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
+func TestParseChoice(t *testing.T) {
+	parse(t, "{0, choice, 0#no files|1#one file|1<{0} files}", []Node{
+		TextNode{},
+		ChoiceArgNode{
+			Arg: Argument{Index: 0},
+			Clauses: []ChoiceClause{
+				{Limit: 0, Nodes: []Node{TextNode{"no files"}}},
+				{Limit: 1, Nodes: []Node{TextNode{"one file"}}},
+				{Limit: 1, Strict: true, Nodes: []Node{TextNode{}, NoneArgNode{Arg: Argument{Index: 0}}, TextNode{" files"}}},
+			},
+		},
+		TextNode{},
+	})
+
+	// Whitespace around limits is ignored, but not in messages.
+	// # and quoted | are literal in messages.
+	parse(t, "{n, choice, -1.5 ≤ below | 0 # #'|'{n, number}}", []Node{
+		TextNode{},
+		ChoiceArgNode{
+			Arg: Argument{Name: "n"},
+			Clauses: []ChoiceClause{
+				{Limit: -1.5, Nodes: []Node{TextNode{" below "}}},
+				{Limit: 0, Nodes: []Node{TextNode{" #|"}, NumberArgNode{Arg: Argument{Name: "n"}}, TextNode{}}},
+			},
+		},
+		TextNode{},
+	})
+
+	test := func(pattern string, expected string) {
+		_, err := Parse(pattern)
+		if err == nil || err.Error() != expected {
+			t.Errorf("%v: unexpected error: %v\n", pattern, err)
+		}
+	}
+
+	test("{n, choice, }", "no choice clauses")
+	test("{n, choice, 0 files}", "unexpected choice clause: 0 files")
+	test("{n, choice, one#one file}", "unexpected choice limit: one")
+	test("{n, choice, 1#one|0#none}", "choice limits must be in ascending order: 1#one|0#none")
+	test("{n, choice, 0#{n}|1#{n, nonsense}}", "unexpected token: nonsense")
+}
+
+func TestParseMarkup(t *testing.T) {
+	parseMarkup := func(s string, expected []Node) {
+		actual, err := Options{Markup: true}.Parse(s)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			actualBytes, _ := json.MarshalIndent(actual, "", "  ")
+			expectedBytes, _ := json.MarshalIndent(expected, "", "  ")
+			t.Errorf("expected\n")
+			t.Errorf("%s\n", string(expectedBytes))
+			t.Errorf("actual\n")
+			t.Errorf("%s\n", string(actualBytes))
+		}
+	}
+
+	parseMarkup("Hello <b>{name}</b>!", []Node{
+		TextNode{"Hello "},
+		MarkupNode{
+			Name: "b",
+			Nodes: []Node{
+				TextNode{},
+				NoneArgNode{Arg: Argument{Name: "name"}},
+				TextNode{},
+			},
+		},
+		TextNode{"!"},
+	})
+
+	parseMarkup("{count, plural, other {<b>#</b> <i><b>cats</b></i>}}", []Node{
+		TextNode{},
+		PluralArgNode{
+			Arg:  Argument{Name: "count"},
+			Kind: "plural",
+			Clauses: []PluralClause{
+				PluralClause{
+					Keyword: "other",
+					Nodes: []Node{
+						TextNode{},
+						MarkupNode{
+							Name: "b",
+							Nodes: []Node{
+								TextNode{},
+								PoundNode{},
+								TextNode{},
+							},
+						},
+						TextNode{" "},
+						MarkupNode{
+							Name: "i",
+							Nodes: []Node{
+								TextNode{},
+								MarkupNode{
+									Name:  "b",
+									Nodes: []Node{TextNode{"cats"}},
+								},
+								TextNode{},
+							},
+						},
+						TextNode{},
+					},
+				},
+			},
+		},
+		TextNode{},
+	})
+
+	// Without markup, tags are text.
+	parse(t, "<b>bold</b>", []Node{
+		TextNode{"<b>bold</b>"},
+	})
+
+	// < is literal unless a well-formed tag follows.
+	parseMarkup("a<b and <b", []Node{
+		TextNode{"a<b and <b"},
+	})
+	// <name/> is the same as <name></name>.
+	parseMarkup("a<br/>b", []Node{
+		TextNode{"a"},
+		MarkupNode{Name: "br", Nodes: []Node{TextNode{}}},
+		TextNode{"b"},
+	})
+}
+
+func TestParseMarkupError(t *testing.T) {
+	parseMarkupError := func(s string, expected string) {
+		_, err := Options{Markup: true}.Parse(s)
+		if err == nil {
+			t.Errorf("%v: expected error\n", s)
+		} else if err.Error() != expected {
+			t.Errorf("%v: %v != %v\n", s, err.Error(), expected)
+		}
+	}
+
+	parseMarkupError("<b>bold", "unexpected token: <EOF>")
+	parseMarkupError("bold</b>", "unexpected token: </b>")
+	parseMarkupError("<b><i>bold</b></i>", "unexpected token: </b>")
+	parseMarkupError("{a, select, other {<b>}}</b>", "unexpected token: }")
+}
+
+func TestParseCustom(t *testing.T) {
+	parse(t, "{price, money} {price, money, EUR} {price, money, ::currency/EUR {x}}", []Node{
+		TextNode{},
+		CustomArgNode{Arg: Argument{Name: "price"}, Type: "money"},
+		TextNode{" "},
+		CustomArgNode{Arg: Argument{Name: "price"}, Type: "money", Style: "EUR"},
+		TextNode{" "},
+		CustomArgNode{Arg: Argument{Name: "price"}, Type: "money", Style: "::currency/EUR {x}"},
+		TextNode{},
+	})
+
+	_, err := Parse("{price, unregistered, EUR}")
+	if err == nil || err.Error() != "unexpected token: unregistered" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
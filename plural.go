@@ -23,22 +23,42 @@ func formToString(form plural.Form) string {
 	panic("unreachable")
 }
 
-// Cardinal determines the cardinal form of number in lang.
+// Cardinal determines the cardinal form of number in lang
+// with the PluralRules set by SetPluralRules.
 func Cardinal(lang language.Tag, number interface{}) (out string, err error) {
-	i, v, w, f, t, err := IVWFT(number)
+	ops, err := PluralOperands(number)
 	if err != nil {
 		return
 	}
-	form := plural.Cardinal.MatchPlural(lang, i, v, w, f, t)
-	return formToString(form), nil
+	return currentPluralRules().Cardinal(lang, ops)
 }
 
-// Ordinal determines the ordinal form of number in lang.
+// Ordinal determines the ordinal form of number in lang
+// with the PluralRules set by SetPluralRules.
 func Ordinal(lang language.Tag, number interface{}) (out string, err error) {
-	i, v, w, f, t, err := IVWFT(number)
+	ops, err := PluralOperands(number)
 	if err != nil {
 		return
 	}
-	form := plural.Ordinal.MatchPlural(lang, i, v, w, f, t)
+	return currentPluralRules().Ordinal(lang, ops)
+}
+
+// XTextPluralRules is the PluralRules of golang.org/x/text/feature/plural.
+// It is the default PluralRules.
+// It ignores the compact exponent operands c and e,
+// so use CLDRPluralRules for compact numbers like 1.2c6.
+type XTextPluralRules struct{}
+
+var _ PluralRules = XTextPluralRules{}
+
+// Cardinal implements PluralRules.
+func (XTextPluralRules) Cardinal(lang language.Tag, ops Operands) (out string, err error) {
+	form := plural.Cardinal.MatchPlural(lang, ops.I, ops.V, ops.W, ops.F, ops.T)
+	return formToString(form), nil
+}
+
+// Ordinal implements PluralRules.
+func (XTextPluralRules) Ordinal(lang language.Tag, ops Operands) (out string, err error) {
+	form := plural.Ordinal.MatchPlural(lang, ops.I, ops.V, ops.W, ops.F, ops.T)
 	return formToString(form), nil
 }
@@ -0,0 +1,234 @@
+package messageformat
+
+import (
+	"strconv"
+	"sync"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+// PluralKind is the kind of plural rules.
+type PluralKind int
+
+const (
+	// PluralKindCardinal is the rules of Cardinal and `{arg, plural}`.
+	PluralKindCardinal PluralKind = iota
+	// PluralKindOrdinal is the rules of Ordinal and `{arg, selectordinal}`.
+	PluralKindOrdinal
+)
+
+func (k PluralKind) String() string {
+	switch k {
+	case PluralKindCardinal:
+		return "cardinal"
+	case PluralKindOrdinal:
+		return "ordinal"
+	}
+	panic("unreachable")
+}
+
+// PluralCategory is a plural category used by a language.
+type PluralCategory struct {
+	// Category is one of zero, one, two, few, many and other.
+	Category string
+	// IntegerSamples are some integers in the category.
+	IntegerSamples []string
+	// DecimalSamples are some decimals in the category.
+	// The samples are strings because the visible fraction digits matter,
+	// for example "1.0" is not in the category one of English.
+	DecimalSamples []string
+}
+
+// maxPluralSamples is the maximum number of samples of each PluralCategory.
+const maxPluralSamples = 8
+
+var pluralCategoryOrder = []string{"zero", "one", "two", "few", "many", "other"}
+
+var pluralIntegerSamples []string
+
+var pluralDecimalSamples []string
+
+func init() {
+	for i := 0; i <= 1000; i++ {
+		pluralIntegerSamples = append(pluralIntegerSamples, strconv.Itoa(i))
+	}
+	for i := 10000; i <= 10000000; i *= 10 {
+		pluralIntegerSamples = append(pluralIntegerSamples, strconv.Itoa(i))
+	}
+	// Compact numbers reach the categories depending on the exponent,
+	// such as many in French.
+	for _, e := range []string{"3", "6"} {
+		for i := 1; i <= 5; i++ {
+			pluralIntegerSamples = append(pluralIntegerSamples, strconv.Itoa(i)+"c"+e)
+		}
+	}
+
+	for i := 0; i <= 100; i++ {
+		pluralDecimalSamples = append(pluralDecimalSamples, strconv.FormatFloat(float64(i)/10, 'f', 1, 64))
+	}
+	for i := 0; i <= 200; i++ {
+		pluralDecimalSamples = append(pluralDecimalSamples, strconv.FormatFloat(float64(i)/100, 'f', 2, 64))
+	}
+}
+
+type pluralCategoriesKey struct {
+	rules PluralRules
+	lang  string
+	kind  PluralKind
+}
+
+var (
+	pluralCategoriesCacheMutex sync.Mutex
+	pluralCategoriesCache      = make(map[pluralCategoriesKey][]PluralCategory)
+)
+
+// PluralCategories returns the plural categories of kind used by lang,
+// in the order of zero, one, two, few, many and other.
+// The categories and their samples are those of icu4c if the PluralRules is ICUPluralRules,
+// and those of CLDRPluralRules otherwise, which may differ from the default XTextPluralRules.
+// The samples are found by selecting the forms of integers up to 1000,
+// powers of 10, compact numbers and decimals with 1 or 2 fraction digits,
+// so a category may have fewer samples.
+// Ordinal categories have no decimal samples.
+func PluralCategories(lang language.Tag, kind PluralKind) (categories []PluralCategory, err error) {
+	// The categories are read from the rules,
+	// so the samples are selected with the same rules.
+	var rules PluralRules = CLDRPluralRules{}
+	if _, ok := currentPluralRules().(ICUPluralRules); ok {
+		rules = ICUPluralRules{}
+	}
+
+	key := pluralCategoriesKey{rules: rules, lang: lang.String(), kind: kind}
+	pluralCategoriesCacheMutex.Lock()
+	cached, ok := pluralCategoriesCache[key]
+	pluralCategoriesCacheMutex.Unlock()
+	if ok {
+		return copyPluralCategories(cached), nil
+	}
+
+	categories, err = selectPluralCategories(rules, lang, kind)
+	if err != nil {
+		return
+	}
+
+	pluralCategoriesCacheMutex.Lock()
+	pluralCategoriesCache[key] = categories
+	pluralCategoriesCacheMutex.Unlock()
+	return copyPluralCategories(categories), nil
+}
+
+func copyPluralCategories(categories []PluralCategory) []PluralCategory {
+	out := make([]PluralCategory, len(categories))
+	for i, c := range categories {
+		out[i] = PluralCategory{
+			Category:       c.Category,
+			IntegerSamples: append([]string(nil), c.IntegerSamples...),
+			DecimalSamples: append([]string(nil), c.DecimalSamples...),
+		}
+	}
+	return out
+}
+
+// pluralCategoryKeywords returns the categories of kind used by lang,
+// without selecting any forms.
+func pluralCategoryKeywords(rules PluralRules, lang language.Tag, kind PluralKind) (keywords map[string]struct{}, err error) {
+	keywords = map[string]struct{}{"other": struct{}{}}
+
+	if _, ok := rules.(ICUPluralRules); ok {
+		var pluralType icu4c.PluralType = icu4c.PluralTypeCardinal
+		if kind == PluralKindOrdinal {
+			pluralType = icu4c.PluralTypeOrdinal
+		}
+		var icuKeywords []string
+		icuKeywords, err = icu4c.PluralKeywords(lang, pluralType)
+		if err != nil {
+			return
+		}
+		for _, keyword := range icuKeywords {
+			keywords[keyword] = struct{}{}
+		}
+		return
+	}
+
+	data := cldrCardinalRules
+	if kind == PluralKindOrdinal {
+		data = cldrOrdinalRules
+	}
+	list, err := lookupCLDRPluralRules(data, lang)
+	if err != nil {
+		return
+	}
+	for _, rule := range list {
+		keywords[rule.Category] = struct{}{}
+	}
+	return
+}
+
+func selectPluralCategories(rules PluralRules, lang language.Tag, kind PluralKind) (categories []PluralCategory, err error) {
+	keywords, err := pluralCategoryKeywords(rules, lang, kind)
+	if err != nil {
+		return
+	}
+
+	byCategory := map[string]*PluralCategory{}
+	for _, form := range pluralCategoryOrder {
+		if _, ok := keywords[form]; ok {
+			byCategory[form] = &PluralCategory{Category: form}
+		}
+	}
+
+	selectForm := rules.Cardinal
+	if kind == PluralKindOrdinal {
+		selectForm = rules.Ordinal
+	}
+
+	// collect appends samples to their categories until every category is full.
+	collect := func(samples []string, field func(c *PluralCategory) *[]string) error {
+		full := 0
+		for _, sample := range samples {
+			if full == len(byCategory) {
+				break
+			}
+			ops, err := PluralOperands(sample)
+			if err != nil {
+				return err
+			}
+			form, err := selectForm(lang, ops)
+			if err != nil {
+				return err
+			}
+			c, ok := byCategory[form]
+			if !ok {
+				continue
+			}
+			s := field(c)
+			if len(*s) < maxPluralSamples {
+				*s = append(*s, sample)
+				if len(*s) == maxPluralSamples {
+					full++
+				}
+			}
+		}
+		return nil
+	}
+
+	err = collect(pluralIntegerSamples, func(c *PluralCategory) *[]string { return &c.IntegerSamples })
+	if err != nil {
+		return
+	}
+	if kind == PluralKindCardinal {
+		err = collect(pluralDecimalSamples, func(c *PluralCategory) *[]string { return &c.DecimalSamples })
+		if err != nil {
+			return
+		}
+	}
+
+	for _, form := range pluralCategoryOrder {
+		if c, ok := byCategory[form]; ok {
+			categories = append(categories, *c)
+		}
+	}
+	return
+}
@@ -0,0 +1,207 @@
+package messageformat
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestPluralCategories(t *testing.T) {
+	test := func(lang string, kind PluralKind, expected []PluralCategory) {
+		actual, err := PluralCategories(language.Make(lang), kind)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else {
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s %v: %+v != %+v\n", lang, kind, actual, expected)
+			}
+		}
+	}
+
+	test("en", PluralKindCardinal, []PluralCategory{
+		{
+			Category:       "one",
+			IntegerSamples: []string{"1"},
+		},
+		{
+			Category:       "other",
+			IntegerSamples: []string{"0", "2", "3", "4", "5", "6", "7", "8"},
+			DecimalSamples: []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7"},
+		},
+	})
+	test("en", PluralKindOrdinal, []PluralCategory{
+		{
+			Category:       "one",
+			IntegerSamples: []string{"1", "21", "31", "41", "51", "61", "71", "81"},
+		},
+		{
+			Category:       "two",
+			IntegerSamples: []string{"2", "22", "32", "42", "52", "62", "72", "82"},
+		},
+		{
+			Category:       "few",
+			IntegerSamples: []string{"3", "23", "33", "43", "53", "63", "73", "83"},
+		},
+		{
+			Category:       "other",
+			IntegerSamples: []string{"0", "4", "5", "6", "7", "8", "9", "10"},
+		},
+	})
+	test("fr", PluralKindCardinal, []PluralCategory{
+		{
+			Category:       "one",
+			IntegerSamples: []string{"0", "1"},
+			DecimalSamples: []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7"},
+		},
+		{
+			Category:       "many",
+			IntegerSamples: []string{"1000000", "10000000", "1c6", "2c6", "3c6", "4c6", "5c6"},
+		},
+		{
+			Category:       "other",
+			IntegerSamples: []string{"2", "3", "4", "5", "6", "7", "8", "9"},
+			DecimalSamples: []string{"2.0", "2.1", "2.2", "2.3", "2.4", "2.5", "2.6", "2.7"},
+		},
+	})
+	test("ru", PluralKindCardinal, []PluralCategory{
+		{
+			Category:       "one",
+			IntegerSamples: []string{"1", "21", "31", "41", "51", "61", "71", "81"},
+		},
+		{
+			Category:       "few",
+			IntegerSamples: []string{"2", "3", "4", "22", "23", "24", "32", "33"},
+		},
+		{
+			Category:       "many",
+			IntegerSamples: []string{"0", "5", "6", "7", "8", "9", "10", "11"},
+		},
+		{
+			Category:       "other",
+			DecimalSamples: []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7"},
+		},
+	})
+	test("zh", PluralKindOrdinal, []PluralCategory{
+		{
+			Category:       "other",
+			IntegerSamples: []string{"0", "1", "2", "3", "4", "5", "6", "7"},
+		},
+	})
+
+	// The categories and the samples are those of CLDR 42,
+	// even if golang.org/x/text/feature/plural selects other forms.
+	test("mt", PluralKindCardinal, []PluralCategory{
+		{
+			Category:       "one",
+			IntegerSamples: []string{"1"},
+			DecimalSamples: []string{"1.0", "1.00"},
+		},
+		{
+			Category:       "two",
+			IntegerSamples: []string{"2"},
+			DecimalSamples: []string{"2.0", "2.00"},
+		},
+		{
+			Category:       "few",
+			IntegerSamples: []string{"0", "3", "4", "5", "6", "7", "8", "9"},
+			DecimalSamples: []string{"0.0", "3.0", "4.0", "5.0", "6.0", "7.0", "8.0", "9.0"},
+		},
+		{
+			Category:       "many",
+			IntegerSamples: []string{"11", "12", "13", "14", "15", "16", "17", "18"},
+		},
+		{
+			Category:       "other",
+			IntegerSamples: []string{"20", "21", "22", "23", "24", "25", "26", "27"},
+			DecimalSamples: []string{"0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8"},
+		},
+	})
+	test("he", PluralKindCardinal, []PluralCategory{
+		{
+			Category:       "one",
+			IntegerSamples: []string{"1"},
+			DecimalSamples: []string{"0.0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7"},
+		},
+		{
+			Category:       "two",
+			IntegerSamples: []string{"2"},
+		},
+		{
+			Category:       "other",
+			IntegerSamples: []string{"0", "3", "4", "5", "6", "7", "8", "9"},
+			DecimalSamples: []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7"},
+		},
+	})
+
+	// Every sample selects its category.
+	for _, lang := range []string{"ar", "cy", "he", "lv", "mt", "pl", "sl"} {
+		tag := language.Make(lang)
+		categories, err := PluralCategories(tag, PluralKindCardinal)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			continue
+		}
+		for _, c := range categories {
+			for _, sample := range append(c.IntegerSamples, c.DecimalSamples...) {
+				ops, err := PluralOperands(sample)
+				if err != nil {
+					t.Errorf("err: %v\n", err)
+					continue
+				}
+				form, err := CLDRPluralRules{}.Cardinal(tag, ops)
+				if err != nil {
+					t.Errorf("err: %v\n", err)
+				} else if form != c.Category {
+					t.Errorf("%s %v: %v != %v\n", lang, sample, form, c.Category)
+				}
+			}
+		}
+	}
+}
+
+type funcPluralRules func(lang language.Tag, ops Operands) string
+
+func (f funcPluralRules) Cardinal(lang language.Tag, ops Operands) (string, error) {
+	return f(lang, ops), nil
+}
+
+func (f funcPluralRules) Ordinal(lang language.Tag, ops Operands) (string, error) {
+	return f(lang, ops), nil
+}
+
+func TestPluralCategoriesRules(t *testing.T) {
+	defer SetPluralRules(XTextPluralRules{})
+
+	expected, err := PluralCategories(language.English, PluralKindCardinal)
+	if err != nil {
+		t.Fatalf("err: %v\n", err)
+	}
+
+	// Other PluralRules than ICUPluralRules do not change the categories and the samples.
+	SetPluralRules(funcPluralRules(func(lang language.Tag, ops Operands) string {
+		return "other"
+	}))
+	actual, err := PluralCategories(language.English, PluralKindCardinal)
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("%+v != %+v\n", actual, expected)
+	}
+}
+
+func TestPluralCategoriesCache(t *testing.T) {
+	first, err := PluralCategories(language.English, PluralKindCardinal)
+	if err != nil {
+		t.Fatalf("err: %v\n", err)
+	}
+	first[0].IntegerSamples[0] = "42"
+
+	second, err := PluralCategories(language.English, PluralKindCardinal)
+	if err != nil {
+		t.Fatalf("err: %v\n", err)
+	}
+	if second[0].IntegerSamples[0] != "1" {
+		t.Errorf("the cached samples are modified: %v\n", second[0].IntegerSamples)
+	}
+}
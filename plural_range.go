@@ -0,0 +1,41 @@
+package messageformat
+
+import (
+	"golang.org/x/text/language"
+)
+
+type pluralRange struct {
+	Start string
+	End   string
+}
+
+// CardinalRange determines the cardinal form of the range from start to end in lang,
+// such as "1–3 items".
+// The forms of start and end are determined by Cardinal
+// and the form of the range is resolved with the plural ranges of CLDR 42, see
+// https://unicode.org/reports/tr35/tr35-numbers.html#Plural_Ranges
+// Like icu4c, the form is other if the plural ranges of lang
+// do not list the forms of start and end, or if lang has no plural ranges at all.
+func CardinalRange(lang language.Tag, start interface{}, end interface{}) (out string, err error) {
+	startForm, err := Cardinal(lang, start)
+	if err != nil {
+		return
+	}
+	endForm, err := Cardinal(lang, end)
+	if err != nil {
+		return
+	}
+	return pluralRangeForm(lang, startForm, endForm), nil
+}
+
+func pluralRangeForm(lang language.Tag, start string, end string) string {
+	// Guessed languages have no plural ranges, like lookupCLDRPluralRules.
+	base, confidence := lang.Base()
+	if confidence != language.Exact {
+		return "other"
+	}
+	if form, ok := cldrPluralRanges[base.String()][pluralRange{start, end}]; ok {
+		return form
+	}
+	return "other"
+}
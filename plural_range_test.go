@@ -0,0 +1,79 @@
+package messageformat
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestCardinalRange(t *testing.T) {
+	test := func(lang string, start interface{}, end interface{}, expected string) {
+		actual, err := CardinalRange(language.Make(lang), start, end)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else {
+			if actual != expected {
+				t.Errorf("%s %v–%v: %v != %v\n", lang, start, end, actual, expected)
+			}
+		}
+	}
+
+	test("en", 1, 3, "other")
+	test("en", 0, 1, "other")
+	test("en", 1.5, 2, "other")
+	test("en-US", 0, 1, "other")
+
+	test("zh", 1, 3, "other")
+
+	test("fr", 0, 1, "one")
+	test("fr", 1, 2, "other")
+
+	test("fa", 0, 1, "other")
+	test("fa", 0, 2, "other")
+
+	test("ro", 0, 1, "few")
+	test("ro", 1, 2, "few")
+	test("ro", 2, 20, "other")
+
+	test("sl", 1, 101, "few")
+	test("sl", 5, 101, "few")
+	test("sl", 1, 2, "two")
+
+	test("lv", 1, 10, "other")
+	test("lv", 0, 1, "one")
+
+	test("ar", 0, 1, "zero")
+	test("ar", 1, 2, "other")
+	test("ar", 0, 3, "few")
+
+	test("ka", 1, 2, "one")
+	test("ka", 0, 1, "other")
+
+	test("ru", 1, 2, "few")
+	test("ru", 1, 5, "many")
+	test("ru", 2, 21, "one")
+
+	// The forms missing in the plural ranges are other, like icu4c.
+	test("ga", 1, 1, "other")
+	test("it", 1, 1, "other")
+	test("cs", 3, 1, "other")
+	test("fr", 2, 1, "other")
+	test("de", 2, 1, "one")
+
+	// Languages without plural ranges are always other, like icu4c.
+	test("mt", 0, 1, "other")
+	test("mt", 1, 2, "other")
+	test("und", 1, 2, "other")
+}
+
+func TestCardinalRangeError(t *testing.T) {
+	test := func(start interface{}, end interface{}) {
+		_, err := CardinalRange(language.English, start, end)
+		if err == nil {
+			t.Errorf("%v–%v: expected error\n", start, end)
+		}
+	}
+
+	test(true, 1)
+	test(1, "a")
+}
@@ -0,0 +1,339 @@
+// This file is generated from the pluralRanges data of CLDR 42, as shipped with ICU 72.
+
+package messageformat
+
+// cldrPluralRanges maps languages to their plural ranges.
+var cldrPluralRanges = map[string]map[pluralRange]string{
+	"af":  pluralRanges04,
+	"ak":  pluralRanges07,
+	"am":  pluralRanges01,
+	"an":  pluralRanges04,
+	"ar":  pluralRanges21,
+	"as":  pluralRanges01,
+	"az":  pluralRanges03,
+	"be":  pluralRanges18,
+	"bg":  pluralRanges04,
+	"bn":  pluralRanges01,
+	"bs":  pluralRanges12,
+	"ca":  pluralRanges15,
+	"cs":  pluralRanges17,
+	"cy":  pluralRanges20,
+	"da":  pluralRanges05,
+	"de":  pluralRanges03,
+	"el":  pluralRanges03,
+	"en":  pluralRanges04,
+	"es":  pluralRanges15,
+	"et":  pluralRanges04,
+	"eu":  pluralRanges04,
+	"fa":  pluralRanges07,
+	"fi":  pluralRanges04,
+	"fil": pluralRanges05,
+	"fr":  pluralRanges13,
+	"ga":  pluralRanges19,
+	"gl":  pluralRanges03,
+	"gsw": pluralRanges03,
+	"gu":  pluralRanges01,
+	"he":  pluralRanges10,
+	"hi":  pluralRanges01,
+	"hr":  pluralRanges12,
+	"hu":  pluralRanges03,
+	"hy":  pluralRanges01,
+	"ia":  pluralRanges04,
+	"id":  pluralRanges00,
+	"io":  pluralRanges04,
+	"is":  pluralRanges05,
+	"it":  pluralRanges14,
+	"ja":  pluralRanges00,
+	"ka":  pluralRanges02,
+	"kk":  pluralRanges03,
+	"km":  pluralRanges00,
+	"kn":  pluralRanges01,
+	"ko":  pluralRanges00,
+	"ky":  pluralRanges03,
+	"lij": pluralRanges03,
+	"lo":  pluralRanges00,
+	"lt":  pluralRanges18,
+	"lv":  pluralRanges09,
+	"mk":  pluralRanges08,
+	"ml":  pluralRanges03,
+	"mn":  pluralRanges03,
+	"mr":  pluralRanges01,
+	"ms":  pluralRanges00,
+	"my":  pluralRanges00,
+	"nb":  pluralRanges04,
+	"ne":  pluralRanges03,
+	"nl":  pluralRanges03,
+	"no":  pluralRanges04,
+	"or":  pluralRanges07,
+	"pa":  pluralRanges05,
+	"pcm": pluralRanges04,
+	"pl":  pluralRanges17,
+	"ps":  pluralRanges01,
+	"pt":  pluralRanges13,
+	"ro":  pluralRanges11,
+	"ru":  pluralRanges18,
+	"sc":  pluralRanges03,
+	"scn": pluralRanges03,
+	"sd":  pluralRanges07,
+	"si":  pluralRanges06,
+	"sk":  pluralRanges17,
+	"sl":  pluralRanges16,
+	"sq":  pluralRanges03,
+	"sr":  pluralRanges12,
+	"sv":  pluralRanges04,
+	"sw":  pluralRanges03,
+	"ta":  pluralRanges03,
+	"te":  pluralRanges03,
+	"th":  pluralRanges00,
+	"tk":  pluralRanges03,
+	"tr":  pluralRanges03,
+	"ug":  pluralRanges03,
+	"uk":  pluralRanges18,
+	"ur":  pluralRanges04,
+	"uz":  pluralRanges03,
+	"vi":  pluralRanges00,
+	"yue": pluralRanges00,
+	"zh":  pluralRanges00,
+	"zu":  pluralRanges01,
+}
+
+var pluralRanges00 = map[pluralRange]string{
+	{"other", "other"}: "other",
+}
+
+var pluralRanges01 = map[pluralRange]string{
+	{"one", "one"}:     "one",
+	{"one", "other"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges02 = map[pluralRange]string{
+	{"one", "other"}:   "one",
+	{"other", "one"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges03 = map[pluralRange]string{
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "one",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges04 = map[pluralRange]string{
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges05 = map[pluralRange]string{
+	{"one", "one"}:     "one",
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "one",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges06 = map[pluralRange]string{
+	{"one", "one"}:     "one",
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges07 = map[pluralRange]string{
+	{"one", "one"}:     "other",
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "one",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges08 = map[pluralRange]string{
+	{"one", "one"}:     "other",
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges09 = map[pluralRange]string{
+	{"zero", "zero"}:   "other",
+	{"zero", "one"}:    "one",
+	{"zero", "other"}:  "other",
+	{"one", "zero"}:    "other",
+	{"one", "one"}:     "one",
+	{"one", "other"}:   "other",
+	{"other", "zero"}:  "other",
+	{"other", "one"}:   "one",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges10 = map[pluralRange]string{
+	{"one", "two"}:     "other",
+	{"one", "other"}:   "other",
+	{"two", "other"}:   "other",
+	{"other", "one"}:   "other",
+	{"other", "two"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges11 = map[pluralRange]string{
+	{"one", "few"}:     "few",
+	{"one", "other"}:   "other",
+	{"few", "one"}:     "few",
+	{"few", "few"}:     "few",
+	{"few", "other"}:   "other",
+	{"other", "few"}:   "few",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges12 = map[pluralRange]string{
+	{"one", "one"}:     "one",
+	{"one", "few"}:     "few",
+	{"one", "other"}:   "other",
+	{"few", "one"}:     "one",
+	{"few", "few"}:     "few",
+	{"few", "other"}:   "other",
+	{"other", "one"}:   "one",
+	{"other", "few"}:   "few",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges13 = map[pluralRange]string{
+	{"one", "one"}:     "one",
+	{"one", "other"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges14 = map[pluralRange]string{
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "one",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges15 = map[pluralRange]string{
+	{"one", "other"}:   "other",
+	{"other", "one"}:   "other",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges16 = map[pluralRange]string{
+	{"one", "one"}:     "few",
+	{"one", "two"}:     "two",
+	{"one", "few"}:     "few",
+	{"one", "other"}:   "other",
+	{"two", "one"}:     "few",
+	{"two", "two"}:     "two",
+	{"two", "few"}:     "few",
+	{"two", "other"}:   "other",
+	{"few", "one"}:     "few",
+	{"few", "two"}:     "two",
+	{"few", "few"}:     "few",
+	{"few", "other"}:   "other",
+	{"other", "one"}:   "few",
+	{"other", "two"}:   "two",
+	{"other", "few"}:   "few",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges17 = map[pluralRange]string{
+	{"one", "few"}:     "few",
+	{"one", "many"}:    "many",
+	{"one", "other"}:   "other",
+	{"few", "few"}:     "few",
+	{"few", "many"}:    "many",
+	{"few", "other"}:   "other",
+	{"many", "one"}:    "one",
+	{"many", "few"}:    "few",
+	{"many", "many"}:   "many",
+	{"many", "other"}:  "other",
+	{"other", "one"}:   "one",
+	{"other", "few"}:   "few",
+	{"other", "many"}:  "many",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges18 = map[pluralRange]string{
+	{"one", "one"}:     "one",
+	{"one", "few"}:     "few",
+	{"one", "many"}:    "many",
+	{"one", "other"}:   "other",
+	{"few", "one"}:     "one",
+	{"few", "few"}:     "few",
+	{"few", "many"}:    "many",
+	{"few", "other"}:   "other",
+	{"many", "one"}:    "one",
+	{"many", "few"}:    "few",
+	{"many", "many"}:   "many",
+	{"many", "other"}:  "other",
+	{"other", "one"}:   "one",
+	{"other", "few"}:   "few",
+	{"other", "many"}:  "many",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges19 = map[pluralRange]string{
+	{"one", "two"}:     "two",
+	{"one", "few"}:     "few",
+	{"one", "many"}:    "many",
+	{"one", "other"}:   "other",
+	{"two", "few"}:     "few",
+	{"two", "many"}:    "many",
+	{"two", "other"}:   "other",
+	{"few", "few"}:     "few",
+	{"few", "many"}:    "many",
+	{"few", "other"}:   "other",
+	{"many", "many"}:   "many",
+	{"many", "other"}:  "other",
+	{"other", "one"}:   "one",
+	{"other", "two"}:   "two",
+	{"other", "few"}:   "few",
+	{"other", "many"}:  "many",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges20 = map[pluralRange]string{
+	{"zero", "one"}:    "one",
+	{"zero", "two"}:    "two",
+	{"zero", "few"}:    "few",
+	{"zero", "many"}:   "many",
+	{"zero", "other"}:  "other",
+	{"one", "two"}:     "two",
+	{"one", "few"}:     "few",
+	{"one", "many"}:    "many",
+	{"one", "other"}:   "other",
+	{"two", "few"}:     "few",
+	{"two", "many"}:    "many",
+	{"two", "other"}:   "other",
+	{"few", "many"}:    "many",
+	{"few", "other"}:   "other",
+	{"many", "other"}:  "other",
+	{"other", "one"}:   "one",
+	{"other", "two"}:   "two",
+	{"other", "few"}:   "few",
+	{"other", "many"}:  "many",
+	{"other", "other"}: "other",
+}
+
+var pluralRanges21 = map[pluralRange]string{
+	{"zero", "one"}:    "zero",
+	{"zero", "two"}:    "zero",
+	{"zero", "few"}:    "few",
+	{"zero", "many"}:   "many",
+	{"zero", "other"}:  "other",
+	{"one", "two"}:     "other",
+	{"one", "few"}:     "few",
+	{"one", "many"}:    "many",
+	{"one", "other"}:   "other",
+	{"two", "few"}:     "few",
+	{"two", "many"}:    "many",
+	{"two", "other"}:   "other",
+	{"few", "few"}:     "few",
+	{"few", "many"}:    "many",
+	{"few", "other"}:   "other",
+	{"many", "few"}:    "few",
+	{"many", "many"}:   "many",
+	{"many", "other"}:  "other",
+	{"other", "one"}:   "other",
+	{"other", "two"}:   "other",
+	{"other", "few"}:   "few",
+	{"other", "many"}:  "many",
+	{"other", "other"}: "other",
+}
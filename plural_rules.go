@@ -0,0 +1,424 @@
+package messageformat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+// PluralRules selects the plural forms of numbers.
+// The forms are zero, one, two, few, many and other.
+type PluralRules interface {
+	// Cardinal selects the cardinal form of ops in lang.
+	Cardinal(lang language.Tag, ops Operands) (string, error)
+	// Ordinal selects the ordinal form of ops in lang.
+	Ordinal(lang language.Tag, ops Operands) (string, error)
+}
+
+var (
+	pluralRulesMutex sync.RWMutex
+	pluralRules      PluralRules = XTextPluralRules{}
+)
+
+// SetPluralRules sets the PluralRules used by Cardinal and Ordinal,
+// and in turn by plural and selectordinal arguments.
+// The default is XTextPluralRules.
+// If rules is nil, it panics.
+func SetPluralRules(rules PluralRules) {
+	pluralRulesMutex.Lock()
+	defer pluralRulesMutex.Unlock()
+
+	if rules == nil {
+		panic("messageformat: SetPluralRules rules is nil")
+	}
+	pluralRules = rules
+}
+
+func currentPluralRules() PluralRules {
+	pluralRulesMutex.RLock()
+	defer pluralRulesMutex.RUnlock()
+
+	return pluralRules
+}
+
+// CLDRPluralRules evaluates the plural rules of CLDR 42 embedded in this package,
+// which is the version of ICU 72.
+// Unlike XTextPluralRules, it does not depend on the version of golang.org/x/text.
+type CLDRPluralRules struct{}
+
+var _ PluralRules = CLDRPluralRules{}
+
+// Cardinal implements PluralRules.
+func (CLDRPluralRules) Cardinal(lang language.Tag, ops Operands) (out string, err error) {
+	rules, err := lookupCLDRPluralRules(cldrCardinalRules, lang)
+	if err != nil {
+		return
+	}
+	return rules.Select(ops), nil
+}
+
+// Ordinal implements PluralRules.
+func (CLDRPluralRules) Ordinal(lang language.Tag, ops Operands) (out string, err error) {
+	rules, err := lookupCLDRPluralRules(cldrOrdinalRules, lang)
+	if err != nil {
+		return
+	}
+	return rules.Select(ops), nil
+}
+
+// ICUPluralRules selects the plural forms with icu4c,
+// so that plural forms and dates are formatted with the same CLDR data.
+type ICUPluralRules struct{}
+
+var _ PluralRules = ICUPluralRules{}
+
+// Cardinal implements PluralRules.
+func (ICUPluralRules) Cardinal(lang language.Tag, ops Operands) (out string, err error) {
+	skeleton, decimal := icuPluralDecimal(ops)
+	return icu4c.PluralSelectFormatted(lang, icu4c.PluralTypeCardinal, skeleton, decimal)
+}
+
+// Ordinal implements PluralRules.
+func (ICUPluralRules) Ordinal(lang language.Tag, ops Operands) (out string, err error) {
+	skeleton, decimal := icuPluralDecimal(ops)
+	return icu4c.PluralSelectFormatted(lang, icu4c.PluralTypeOrdinal, skeleton, decimal)
+}
+
+// icuPluralDecimal returns the decimal of ops with the skeleton
+// that keeps its visible fraction digits.
+// The decimal is reconstructed from I and F only if ops is not from PluralOperands.
+// A compact number is formatted in the compact notation of the locale,
+// whose exponent may differ from C.
+func icuPluralDecimal(ops Operands) (skeleton string, decimal string) {
+	decimal = ops.decimal
+	if decimal == "" {
+		decimal = strconv.Itoa(ops.I)
+		if ops.V > 0 {
+			decimal += "." + fmt.Sprintf("%0*d", ops.V, ops.F)
+		}
+	}
+
+	if ops.C != 0 {
+		skeleton = "compact-short precision-unlimited"
+	} else {
+		skeleton = fractionSkeleton(ops.V)
+	}
+	return
+}
+
+var (
+	compiledPluralRulesMutex sync.Mutex
+	compiledPluralRules      = make(map[string]pluralRuleList)
+)
+
+// lookupCLDRPluralRules looks up the rules of lang by its language and region,
+// and then by its language.
+// Guessed subtags are not used, so for example und has the category other only,
+// like a language without rules.
+func lookupCLDRPluralRules(data map[string]string, lang language.Tag) (rules pluralRuleList, err error) {
+	var source string
+	base, baseConfidence := lang.Base()
+	region, regionConfidence := lang.Region()
+	if baseConfidence == language.Exact {
+		var ok bool
+		if regionConfidence == language.Exact {
+			source, ok = data[base.String()+"-"+region.String()]
+		}
+		if !ok {
+			source = data[base.String()]
+		}
+	}
+
+	compiledPluralRulesMutex.Lock()
+	defer compiledPluralRulesMutex.Unlock()
+
+	rules, ok := compiledPluralRules[source]
+	if ok {
+		return
+	}
+	rules, err = parsePluralRules(source)
+	if err != nil {
+		return
+	}
+	compiledPluralRules[source] = rules
+	return
+}
+
+// pluralRuleList is the rules of a language, in the syntax of
+// https://unicode.org/reports/tr35/tr35-numbers.html#Plural_rules_syntax
+// The category other is implied.
+type pluralRuleList []pluralRule
+
+type pluralRule struct {
+	Category string
+	// Condition is a disjunction of conjunctions.
+	Condition [][]pluralRelation
+}
+
+type pluralRelation struct {
+	Operand byte
+	// Modulus is 0 if the relation has no modulus.
+	Modulus int
+	Negated bool
+	Ranges  []pluralValueRange
+}
+
+type pluralValueRange struct {
+	Low  int
+	High int
+}
+
+func (l pluralRuleList) Select(ops Operands) string {
+	for _, rule := range l {
+		if rule.Match(ops) {
+			return rule.Category
+		}
+	}
+	return "other"
+}
+
+func (r pluralRule) Match(ops Operands) bool {
+	for _, and := range r.Condition {
+		matched := true
+		for _, relation := range and {
+			if !relation.Match(ops) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r pluralRelation) Match(ops Operands) bool {
+	var value int
+	isInteger := true
+	switch r.Operand {
+	case 'n':
+		// n has a fraction if its visible fraction digits are not all zeros.
+		// Such n never equals to a value.
+		value = ops.I
+		isInteger = ops.F == 0
+	case 'i':
+		value = ops.I
+	case 'v':
+		value = ops.V
+	case 'w':
+		value = ops.W
+	case 'f':
+		value = ops.F
+	case 't':
+		value = ops.T
+	case 'c':
+		value = ops.C
+	case 'e':
+		value = ops.E
+	}
+	if r.Modulus != 0 {
+		value = value % r.Modulus
+	}
+
+	in := false
+	if isInteger {
+		for _, rng := range r.Ranges {
+			if value >= rng.Low && value <= rng.High {
+				in = true
+				break
+			}
+		}
+	}
+	return in != r.Negated
+}
+
+var pluralCategories = map[string]struct{}{
+	"zero": struct{}{},
+	"one":  struct{}{},
+	"two":  struct{}{},
+	"few":  struct{}{},
+	"many": struct{}{},
+}
+
+// parsePluralRules parses rules like "one: i = 1 and v = 0; few: n % 10 = 2..4".
+// Samples introduced by @ are ignored.
+func parsePluralRules(s string) (rules pluralRuleList, err error) {
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		idx := strings.IndexByte(part, ':')
+		if idx == -1 {
+			err = fmt.Errorf("invalid plural rule: %v", part)
+			return
+		}
+		category := strings.TrimSpace(part[:idx])
+		if _, ok := pluralCategories[category]; !ok {
+			err = fmt.Errorf("invalid plural category: %v", category)
+			return
+		}
+		var condition [][]pluralRelation
+		condition, err = parsePluralCondition(part[idx+1:])
+		if err != nil {
+			return
+		}
+		rules = append(rules, pluralRule{
+			Category:  category,
+			Condition: condition,
+		})
+	}
+	return
+}
+
+func parsePluralCondition(s string) (condition [][]pluralRelation, err error) {
+	if idx := strings.IndexByte(s, '@'); idx != -1 {
+		s = s[:idx]
+	}
+	tokens := tokenizePluralCondition(s)
+	if len(tokens) == 0 {
+		err = fmt.Errorf("empty plural condition")
+		return
+	}
+
+	p := pluralConditionParser{tokens: tokens}
+	var and []pluralRelation
+	for {
+		var relation pluralRelation
+		relation, err = p.parseRelation()
+		if err != nil {
+			return
+		}
+		and = append(and, relation)
+
+		if p.eof() {
+			condition = append(condition, and)
+			return
+		}
+		switch tok := p.next(); tok {
+		case "and":
+		case "or":
+			condition = append(condition, and)
+			and = nil
+		default:
+			err = fmt.Errorf("unexpected token in plural condition: %v", tok)
+			return
+		}
+	}
+}
+
+func tokenizePluralCondition(s string) (tokens []string) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ':
+			i++
+		case c >= 'a' && c <= 'z':
+			j := i
+			for j < len(s) && s[j] >= 'a' && s[j] <= 'z' {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		case strings.HasPrefix(s[i:], "!=") || strings.HasPrefix(s[i:], ".."):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		default:
+			tokens = append(tokens, s[i:i+1])
+			i++
+		}
+	}
+	return
+}
+
+type pluralConditionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *pluralConditionParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *pluralConditionParser) next() string {
+	if p.eof() {
+		return ""
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok
+}
+
+func (p *pluralConditionParser) parseValue() (value int, err error) {
+	tok := p.next()
+	value, err = strconv.Atoi(tok)
+	if err != nil {
+		err = fmt.Errorf("expected value in plural condition: %v", tok)
+	}
+	return
+}
+
+func (p *pluralConditionParser) parseRelation() (relation pluralRelation, err error) {
+	operand := p.next()
+	if len(operand) != 1 || !strings.Contains("nivwftce", operand) {
+		err = fmt.Errorf("expected operand in plural condition: %v", operand)
+		return
+	}
+	relation.Operand = operand[0]
+
+	op := p.next()
+	if op == "%" {
+		relation.Modulus, err = p.parseValue()
+		if err != nil {
+			return
+		}
+		if relation.Modulus == 0 {
+			err = fmt.Errorf("zero modulus in plural condition")
+			return
+		}
+		op = p.next()
+	}
+	switch op {
+	case "=":
+	case "!=":
+		relation.Negated = true
+	default:
+		err = fmt.Errorf("expected = or != in plural condition: %v", op)
+		return
+	}
+
+	for {
+		var rng pluralValueRange
+		rng.Low, err = p.parseValue()
+		if err != nil {
+			return
+		}
+		rng.High = rng.Low
+		if !p.eof() && p.tokens[p.pos] == ".." {
+			p.pos++
+			rng.High, err = p.parseValue()
+			if err != nil {
+				return
+			}
+		}
+		relation.Ranges = append(relation.Ranges, rng)
+
+		if p.eof() || p.tokens[p.pos] != "," {
+			return
+		}
+		p.pos++
+	}
+}
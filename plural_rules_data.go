@@ -0,0 +1,333 @@
+// This file is generated from the plurals data of CLDR 42, as shipped with ICU 72.
+// The samples are omitted and the category other is implied.
+
+package messageformat
+
+// cldrCardinalRules maps languages to their cardinal rules.
+var cldrCardinalRules = map[string]string{
+	"af":    "one: n = 1",
+	"ak":    "one: n = 0..1",
+	"am":    "one: i = 0 or n = 1",
+	"an":    "one: n = 1",
+	"ar":    "zero: n = 0; one: n = 1; two: n = 2; few: n % 100 = 3..10; many: n % 100 = 11..99",
+	"ars":   "zero: n = 0; one: n = 1; two: n = 2; few: n % 100 = 3..10; many: n % 100 = 11..99",
+	"as":    "one: i = 0 or n = 1",
+	"asa":   "one: n = 1",
+	"ast":   "one: i = 1 and v = 0",
+	"az":    "one: n = 1",
+	"bal":   "one: n = 1",
+	"be":    "one: n % 10 = 1 and n % 100 != 11; few: n % 10 = 2..4 and n % 100 != 12..14; many: n % 10 = 0 or n % 10 = 5..9 or n % 100 = 11..14",
+	"bem":   "one: n = 1",
+	"bez":   "one: n = 1",
+	"bg":    "one: n = 1",
+	"bho":   "one: n = 0..1",
+	"bm":    "",
+	"bn":    "one: i = 0 or n = 1",
+	"bo":    "",
+	"br":    "one: n % 10 = 1 and n % 100 != 11,71,91; two: n % 10 = 2 and n % 100 != 12,72,92; few: n % 10 = 3..4,9 and n % 100 != 10..19,70..79,90..99; many: n != 0 and n % 1000000 = 0",
+	"brx":   "one: n = 1",
+	"bs":    "one: v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14 or f % 10 = 2..4 and f % 100 != 12..14",
+	"ca":    "one: i = 1 and v = 0; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"ce":    "one: n = 1",
+	"ceb":   "one: v = 0 and i = 1,2,3 or v = 0 and i % 10 != 4,6,9 or v != 0 and f % 10 != 4,6,9",
+	"cgg":   "one: n = 1",
+	"chr":   "one: n = 1",
+	"ckb":   "one: n = 1",
+	"cs":    "one: i = 1 and v = 0; few: i = 2..4 and v = 0; many: v != 0",
+	"cy":    "zero: n = 0; one: n = 1; two: n = 2; few: n = 3; many: n = 6",
+	"da":    "one: n = 1 or t != 0 and i = 0,1",
+	"de":    "one: i = 1 and v = 0",
+	"doi":   "one: i = 0 or n = 1",
+	"dsb":   "one: v = 0 and i % 100 = 1 or f % 100 = 1; two: v = 0 and i % 100 = 2 or f % 100 = 2; few: v = 0 and i % 100 = 3..4 or f % 100 = 3..4",
+	"dv":    "one: n = 1",
+	"dz":    "",
+	"ee":    "one: n = 1",
+	"el":    "one: n = 1",
+	"en":    "one: i = 1 and v = 0",
+	"eo":    "one: n = 1",
+	"es":    "one: n = 1; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"et":    "one: i = 1 and v = 0",
+	"eu":    "one: n = 1",
+	"fa":    "one: i = 0 or n = 1",
+	"ff":    "one: i = 0,1",
+	"fi":    "one: i = 1 and v = 0",
+	"fil":   "one: v = 0 and i = 1,2,3 or v = 0 and i % 10 != 4,6,9 or v != 0 and f % 10 != 4,6,9",
+	"fo":    "one: n = 1",
+	"fr":    "one: i = 0,1; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"fur":   "one: n = 1",
+	"fy":    "one: i = 1 and v = 0",
+	"ga":    "one: n = 1; two: n = 2; few: n = 3..6; many: n = 7..10",
+	"gd":    "one: n = 1,11; two: n = 2,12; few: n = 3..10,13..19",
+	"gl":    "one: i = 1 and v = 0",
+	"gsw":   "one: n = 1",
+	"gu":    "one: i = 0 or n = 1",
+	"guw":   "one: n = 0..1",
+	"gv":    "one: v = 0 and i % 10 = 1; two: v = 0 and i % 10 = 2; few: v = 0 and i % 100 = 0,20,40,60,80; many: v != 0",
+	"ha":    "one: n = 1",
+	"haw":   "one: n = 1",
+	"he":    "one: i = 1 and v = 0 or i = 0 and v != 0; two: i = 2 and v = 0",
+	"hi":    "one: i = 0 or n = 1",
+	"hnj":   "",
+	"hr":    "one: v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14 or f % 10 = 2..4 and f % 100 != 12..14",
+	"hsb":   "one: v = 0 and i % 100 = 1 or f % 100 = 1; two: v = 0 and i % 100 = 2 or f % 100 = 2; few: v = 0 and i % 100 = 3..4 or f % 100 = 3..4",
+	"hu":    "one: n = 1",
+	"hy":    "one: i = 0,1",
+	"ia":    "one: i = 1 and v = 0",
+	"id":    "",
+	"ig":    "",
+	"ii":    "",
+	"in":    "",
+	"io":    "one: i = 1 and v = 0",
+	"is":    "one: t = 0 and i % 10 = 1 and i % 100 != 11 or t % 10 = 1 and t % 100 != 11",
+	"it":    "one: i = 1 and v = 0; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"iu":    "one: n = 1; two: n = 2",
+	"iw":    "one: i = 1 and v = 0 or i = 0 and v != 0; two: i = 2 and v = 0",
+	"ja":    "",
+	"jbo":   "",
+	"jgo":   "one: n = 1",
+	"ji":    "one: i = 1 and v = 0",
+	"jmc":   "one: n = 1",
+	"jv":    "",
+	"jw":    "",
+	"ka":    "one: n = 1",
+	"kab":   "one: i = 0,1",
+	"kaj":   "one: n = 1",
+	"kcg":   "one: n = 1",
+	"kde":   "",
+	"kea":   "",
+	"kk":    "one: n = 1",
+	"kkj":   "one: n = 1",
+	"kl":    "one: n = 1",
+	"km":    "",
+	"kn":    "one: i = 0 or n = 1",
+	"ko":    "",
+	"ks":    "one: n = 1",
+	"ksb":   "one: n = 1",
+	"ksh":   "zero: n = 0; one: n = 1",
+	"ku":    "one: n = 1",
+	"kw":    "zero: n = 0; one: n = 1; two: n % 100 = 2,22,42,62,82 or n % 1000 = 0 and n % 100000 = 1000..20000,40000,60000,80000 or n != 0 and n % 1000000 = 100000; few: n % 100 = 3,23,43,63,83; many: n != 1 and n % 100 = 1,21,41,61,81",
+	"ky":    "one: n = 1",
+	"lag":   "zero: n = 0; one: i = 0,1 and n != 0",
+	"lb":    "one: n = 1",
+	"lg":    "one: n = 1",
+	"lij":   "one: i = 1 and v = 0",
+	"lkt":   "",
+	"ln":    "one: n = 0..1",
+	"lo":    "",
+	"lt":    "one: n % 10 = 1 and n % 100 != 11..19; few: n % 10 = 2..9 and n % 100 != 11..19; many: f != 0",
+	"lv":    "zero: n % 10 = 0 or n % 100 = 11..19 or v = 2 and f % 100 = 11..19; one: n % 10 = 1 and n % 100 != 11 or v = 2 and f % 10 = 1 and f % 100 != 11 or v != 2 and f % 10 = 1",
+	"mas":   "one: n = 1",
+	"mg":    "one: n = 0..1",
+	"mgo":   "one: n = 1",
+	"mk":    "one: v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11",
+	"ml":    "one: n = 1",
+	"mn":    "one: n = 1",
+	"mo":    "one: i = 1 and v = 0; few: v != 0 or n = 0 or n != 1 and n % 100 = 1..19",
+	"mr":    "one: n = 1",
+	"ms":    "",
+	"mt":    "one: n = 1; two: n = 2; few: n = 0 or n % 100 = 3..10; many: n % 100 = 11..19",
+	"my":    "",
+	"nah":   "one: n = 1",
+	"naq":   "one: n = 1; two: n = 2",
+	"nb":    "one: n = 1",
+	"nd":    "one: n = 1",
+	"ne":    "one: n = 1",
+	"nl":    "one: i = 1 and v = 0",
+	"nn":    "one: n = 1",
+	"nnh":   "one: n = 1",
+	"no":    "one: n = 1",
+	"nqo":   "",
+	"nr":    "one: n = 1",
+	"nso":   "one: n = 0..1",
+	"ny":    "one: n = 1",
+	"nyn":   "one: n = 1",
+	"om":    "one: n = 1",
+	"or":    "one: n = 1",
+	"os":    "one: n = 1",
+	"osa":   "",
+	"pa":    "one: n = 0..1",
+	"pap":   "one: n = 1",
+	"pcm":   "one: i = 0 or n = 1",
+	"pl":    "one: i = 1 and v = 0; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14; many: v = 0 and i != 1 and i % 10 = 0..1 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 12..14",
+	"prg":   "zero: n % 10 = 0 or n % 100 = 11..19 or v = 2 and f % 100 = 11..19; one: n % 10 = 1 and n % 100 != 11 or v = 2 and f % 10 = 1 and f % 100 != 11 or v != 2 and f % 10 = 1",
+	"ps":    "one: n = 1",
+	"pt":    "one: i = 0..1; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"pt-PT": "one: i = 1 and v = 0; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"rm":    "one: n = 1",
+	"ro":    "one: i = 1 and v = 0; few: v != 0 or n = 0 or n != 1 and n % 100 = 1..19",
+	"rof":   "one: n = 1",
+	"ru":    "one: v = 0 and i % 10 = 1 and i % 100 != 11; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14; many: v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14",
+	"rwk":   "one: n = 1",
+	"sah":   "",
+	"saq":   "one: n = 1",
+	"sat":   "one: n = 1; two: n = 2",
+	"sc":    "one: i = 1 and v = 0",
+	"scn":   "one: i = 1 and v = 0",
+	"sd":    "one: n = 1",
+	"sdh":   "one: n = 1",
+	"se":    "one: n = 1; two: n = 2",
+	"seh":   "one: n = 1",
+	"ses":   "",
+	"sg":    "",
+	"sh":    "one: v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14 or f % 10 = 2..4 and f % 100 != 12..14",
+	"shi":   "one: i = 0 or n = 1; few: n = 2..10",
+	"si":    "one: n = 0,1 or i = 0 and f = 1",
+	"sk":    "one: i = 1 and v = 0; few: i = 2..4 and v = 0; many: v != 0",
+	"sl":    "one: v = 0 and i % 100 = 1; two: v = 0 and i % 100 = 2; few: v = 0 and i % 100 = 3..4 or v != 0",
+	"sma":   "one: n = 1; two: n = 2",
+	"smi":   "one: n = 1; two: n = 2",
+	"smj":   "one: n = 1; two: n = 2",
+	"smn":   "one: n = 1; two: n = 2",
+	"sms":   "one: n = 1; two: n = 2",
+	"sn":    "one: n = 1",
+	"so":    "one: n = 1",
+	"sq":    "one: n = 1",
+	"sr":    "one: v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14 or f % 10 = 2..4 and f % 100 != 12..14",
+	"ss":    "one: n = 1",
+	"ssy":   "one: n = 1",
+	"st":    "one: n = 1",
+	"su":    "",
+	"sv":    "one: i = 1 and v = 0",
+	"sw":    "one: i = 1 and v = 0",
+	"syr":   "one: n = 1",
+	"ta":    "one: n = 1",
+	"te":    "one: n = 1",
+	"teo":   "one: n = 1",
+	"th":    "",
+	"ti":    "one: n = 0..1",
+	"tig":   "one: n = 1",
+	"tk":    "one: n = 1",
+	"tl":    "one: v = 0 and i = 1,2,3 or v = 0 and i % 10 != 4,6,9 or v != 0 and f % 10 != 4,6,9",
+	"tn":    "one: n = 1",
+	"to":    "",
+	"tpi":   "",
+	"tr":    "one: n = 1",
+	"ts":    "one: n = 1",
+	"tzm":   "one: n = 0..1 or n = 11..99",
+	"ug":    "one: n = 1",
+	"uk":    "one: v = 0 and i % 10 = 1 and i % 100 != 11; few: v = 0 and i % 10 = 2..4 and i % 100 != 12..14; many: v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14",
+	"ur":    "one: i = 1 and v = 0",
+	"uz":    "one: n = 1",
+	"ve":    "one: n = 1",
+	"vec":   "one: i = 1 and v = 0; many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5",
+	"vi":    "",
+	"vo":    "one: n = 1",
+	"vun":   "one: n = 1",
+	"wa":    "one: n = 0..1",
+	"wae":   "one: n = 1",
+	"wo":    "",
+	"xh":    "one: n = 1",
+	"xog":   "one: n = 1",
+	"yi":    "one: i = 1 and v = 0",
+	"yo":    "",
+	"yue":   "",
+	"zh":    "",
+	"zu":    "one: i = 0 or n = 1",
+}
+
+// cldrOrdinalRules maps languages to their ordinal rules.
+var cldrOrdinalRules = map[string]string{
+	"af":  "",
+	"am":  "",
+	"an":  "",
+	"ar":  "",
+	"as":  "one: n = 1,5,7,8,9,10; two: n = 2,3; few: n = 4; many: n = 6",
+	"ast": "",
+	"az":  "one: i % 10 = 1,2,5,7,8 or i % 100 = 20,50,70,80; few: i % 10 = 3,4 or i % 1000 = 100,200,300,400,500,600,700,800,900; many: i = 0 or i % 10 = 6 or i % 100 = 40,60,90",
+	"bal": "one: n = 1",
+	"be":  "few: n % 10 = 2,3 and n % 100 != 12,13",
+	"bg":  "",
+	"bn":  "one: n = 1,5,7,8,9,10; two: n = 2,3; few: n = 4; many: n = 6",
+	"bs":  "",
+	"ca":  "one: n = 1,3; two: n = 2; few: n = 4",
+	"ce":  "",
+	"cs":  "",
+	"cy":  "zero: n = 0,7,8,9; one: n = 1; two: n = 2; few: n = 3,4; many: n = 5,6",
+	"da":  "",
+	"de":  "",
+	"dsb": "",
+	"el":  "",
+	"en":  "one: n % 10 = 1 and n % 100 != 11; two: n % 10 = 2 and n % 100 != 12; few: n % 10 = 3 and n % 100 != 13",
+	"es":  "",
+	"et":  "",
+	"eu":  "",
+	"fa":  "",
+	"fi":  "",
+	"fil": "one: n = 1",
+	"fr":  "one: n = 1",
+	"fy":  "",
+	"ga":  "one: n = 1",
+	"gd":  "one: n = 1,11; two: n = 2,12; few: n = 3,13",
+	"gl":  "",
+	"gsw": "",
+	"gu":  "one: n = 1; two: n = 2,3; few: n = 4; many: n = 6",
+	"he":  "",
+	"hi":  "one: n = 1; two: n = 2,3; few: n = 4; many: n = 6",
+	"hr":  "",
+	"hsb": "",
+	"hu":  "one: n = 1,5",
+	"hy":  "one: n = 1",
+	"ia":  "",
+	"id":  "",
+	"in":  "",
+	"is":  "",
+	"it":  "many: n = 11,8,80,800",
+	"iw":  "",
+	"ja":  "",
+	"ka":  "one: i = 1; many: i = 0 or i % 100 = 2..20,40,60,80",
+	"kk":  "many: n % 10 = 6 or n % 10 = 9 or n % 10 = 0 and n != 0",
+	"km":  "",
+	"kn":  "",
+	"ko":  "",
+	"kw":  "one: n = 1..4 or n % 100 = 1..4,21..24,41..44,61..64,81..84; many: n = 5 or n % 100 = 5",
+	"ky":  "",
+	"lij": "many: n = 11,8,80..89,800..899",
+	"lo":  "one: n = 1",
+	"lt":  "",
+	"lv":  "",
+	"mk":  "one: i % 10 = 1 and i % 100 != 11; two: i % 10 = 2 and i % 100 != 12; many: i % 10 = 7,8 and i % 100 != 17,18",
+	"ml":  "",
+	"mn":  "",
+	"mo":  "one: n = 1",
+	"mr":  "one: n = 1; two: n = 2,3; few: n = 4",
+	"ms":  "one: n = 1",
+	"my":  "",
+	"nb":  "",
+	"ne":  "one: n = 1..4",
+	"nl":  "",
+	"no":  "",
+	"or":  "one: n = 1,5,7..9; two: n = 2,3; few: n = 4; many: n = 6",
+	"pa":  "",
+	"pl":  "",
+	"prg": "",
+	"ps":  "",
+	"pt":  "",
+	"ro":  "one: n = 1",
+	"ru":  "",
+	"sc":  "many: n = 11,8,80,800",
+	"scn": "many: n = 11,8,80,800",
+	"sd":  "",
+	"sh":  "",
+	"si":  "",
+	"sk":  "",
+	"sl":  "",
+	"sq":  "one: n = 1; many: n % 10 = 4 and n % 100 != 14",
+	"sr":  "",
+	"sv":  "one: n % 10 = 1,2 and n % 100 != 11,12",
+	"sw":  "",
+	"ta":  "",
+	"te":  "",
+	"th":  "",
+	"tk":  "few: n % 10 = 6,9 or n = 10",
+	"tl":  "one: n = 1",
+	"tpi": "",
+	"tr":  "",
+	"uk":  "few: n % 10 = 3 and n % 100 != 13",
+	"ur":  "",
+	"uz":  "",
+	"vec": "many: n = 11,8,80,800",
+	"vi":  "one: n = 1",
+	"yue": "",
+	"zh":  "",
+	"zu":  "",
+}
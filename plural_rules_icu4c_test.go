@@ -0,0 +1,98 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package messageformat
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestICUPluralRules(t *testing.T) {
+	rules := ICUPluralRules{}
+
+	testCardinal := func(lang string, number interface{}, expected string) {
+		ops, err := PluralOperands(number)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		actual, err := rules.Cardinal(language.Make(lang), ops)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%s %v: %v != %v\n", lang, number, actual, expected)
+		}
+	}
+
+	testCardinal("en", 1, "one")
+	testCardinal("en", "1.0", "other")
+	testCardinal("fr", "1.2c6", "many")
+	testCardinal("he", "0.5", "one")
+	testCardinal("mt", 2, "two")
+	testCardinal("lv", "0.11", "zero")
+	// icu4c receives all the digits and takes i from the last 18 of them.
+	testCardinal("en", "100000000000000000001", "one")
+
+	// ICU 72 has the same CLDR version as CLDRPluralRules.
+	samples := []string{"0", "1", "2", "3", "5", "11", "21", "22", "101", "1000000", "0.0", "0.1", "1.0", "1.5", "0.11", "2.10"}
+	for _, lang := range []string{"ar", "cy", "en", "fr", "he", "lv", "mt", "pl", "pt-PT", "ru", "sl"} {
+		tag := language.Make(lang)
+		for _, sample := range samples {
+			ops, err := PluralOperands(sample)
+			if err != nil {
+				t.Errorf("err: %v\n", err)
+				continue
+			}
+			for _, kind := range []PluralKind{PluralKindCardinal, PluralKindOrdinal} {
+				var expected, actual string
+				var err1, err2 error
+				if kind == PluralKindCardinal {
+					expected, err1 = CLDRPluralRules{}.Cardinal(tag, ops)
+					actual, err2 = rules.Cardinal(tag, ops)
+				} else {
+					if ops.V != 0 {
+						continue
+					}
+					expected, err1 = CLDRPluralRules{}.Ordinal(tag, ops)
+					actual, err2 = rules.Ordinal(tag, ops)
+				}
+				if err1 != nil || err2 != nil {
+					t.Errorf("err: %v %v\n", err1, err2)
+				} else if actual != expected {
+					t.Errorf("%s %v %v: %v != %v\n", lang, kind, sample, actual, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestPluralCategoriesICU(t *testing.T) {
+	defer SetPluralRules(XTextPluralRules{})
+	SetPluralRules(ICUPluralRules{})
+
+	test := func(lang string, kind PluralKind, expected []string) {
+		categories, err := PluralCategories(language.Make(lang), kind)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		var actual []string
+		for _, c := range categories {
+			actual = append(actual, c.Category)
+			if len(c.IntegerSamples) == 0 {
+				t.Errorf("%s %v %s: no samples\n", lang, kind, c.Category)
+			}
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s %v: %v != %v\n", lang, kind, actual, expected)
+		}
+	}
+
+	test("en", PluralKindOrdinal, []string{"one", "two", "few", "other"})
+	test("fr", PluralKindCardinal, []string{"one", "many", "other"})
+	test("mt", PluralKindCardinal, []string{"one", "two", "few", "many", "other"})
+	test("zh", PluralKindCardinal, []string{"other"})
+}
@@ -0,0 +1,183 @@
+package messageformat
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestParsePluralRules(t *testing.T) {
+	test := func(rules string, number interface{}, expected string) {
+		l, err := parsePluralRules(rules)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		ops, err := PluralOperands(number)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		actual := l.Select(ops)
+		if actual != expected {
+			t.Errorf("%v %v: %v != %v\n", rules, number, actual, expected)
+		}
+	}
+
+	test("", 1, "other")
+	test("one: n = 1", 1, "one")
+	test("one: n = 1", "1.0", "one")
+	test("one: n = 1", "1.5", "other")
+	test("one: n != 1", "1.5", "one")
+	test("one: i = 1 and v = 0", "1.0", "other")
+	test("one: i = 0,1", "1.5", "one")
+	test("few: n % 10 = 2..4 and n % 100 != 12..14", 22, "few")
+	test("few: n % 10 = 2..4 and n % 100 != 12..14", 12, "other")
+	test("few: n % 10 = 2..4 and n % 100 != 12..14", "2.5", "other")
+	test("one: n = 1 or n = 3", 3, "one")
+	test("one: n = 1 @integer 1 @decimal 1.0, 1.00", 1, "one")
+	test("zero: n = 0; one: n = 1; two: n = 2", 2, "two")
+	test("one: f % 10 = 1 and f % 100 != 11", "0.21", "one")
+	test("one: t = 1", "0.10", "one")
+	test("one: w = 2", "0.120", "one")
+	test("many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5", 1000000, "many")
+	test("many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5", "1c6", "many")
+	test("many: e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5", "1c3", "other")
+	test("many: c = 6", "1.5c6", "many")
+}
+
+func TestParsePluralRulesError(t *testing.T) {
+	test := func(rules string) {
+		_, err := parsePluralRules(rules)
+		if err == nil {
+			t.Errorf("%v: expected error\n", rules)
+		}
+	}
+
+	test("one")
+	test("other: n = 1")
+	test("one:")
+	test("one: x = 1")
+	test("one: n")
+	test("one: n == 1")
+	test("one: n % 0 = 1")
+	test("one: n = a")
+	test("one: n = 1..")
+	test("one: n = 1 xor n = 2")
+	test("one: n = 1 and")
+}
+
+func TestCLDRPluralRules(t *testing.T) {
+	rules := CLDRPluralRules{}
+
+	testCardinal := func(lang string, number interface{}, expected string) {
+		ops, err := PluralOperands(number)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		actual, err := rules.Cardinal(language.Make(lang), ops)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%s %v: %v != %v\n", lang, number, actual, expected)
+		}
+	}
+	testOrdinal := func(lang string, number interface{}, expected string) {
+		ops, err := PluralOperands(number)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		actual, err := rules.Ordinal(language.Make(lang), ops)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%s %v: %v != %v\n", lang, number, actual, expected)
+		}
+	}
+
+	testCardinal("en", 1, "one")
+	testCardinal("en", "1.0", "other")
+	testCardinal("en-US", 1, "one")
+	testCardinal("zh", 1, "other")
+	testCardinal("und", 1, "other")
+
+	testCardinal("fr", 0, "one")
+	testCardinal("fr", 1000000, "many")
+	testCardinal("fr", "1.2c6", "many")
+
+	testCardinal("pt", 0, "one")
+	testCardinal("pt-PT", 0, "other")
+	testCardinal("pt-PT", 1, "one")
+
+	testCardinal("ar", 0, "zero")
+	testCardinal("ar", 2, "two")
+	testCardinal("ar", 103, "few")
+	testCardinal("ar", 111, "many")
+	testCardinal("ar", 100, "other")
+
+	testCardinal("ru", 21, "one")
+	testCardinal("ru", 22, "few")
+	testCardinal("ru", 11, "many")
+	testCardinal("ru", "1.5", "other")
+
+	// These differ from golang.org/x/text v0.3.2.
+	testCardinal("he", "0.5", "one")
+	testCardinal("mt", 2, "two")
+	testCardinal("is", "0.2", "other")
+	testCardinal("kw", 3, "few")
+
+	testOrdinal("en", 1, "one")
+	testOrdinal("en", 22, "two")
+	testOrdinal("en", 13, "other")
+	testOrdinal("cy", 7, "zero")
+	testOrdinal("gd", 3, "few")
+	testOrdinal("ru", 1, "other")
+}
+
+func TestSetPluralRules(t *testing.T) {
+	defer SetPluralRules(XTextPluralRules{})
+
+	test := func(expected string) {
+		actual, err := Cardinal(language.Make("mt"), 2)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v != %v\n", actual, expected)
+		}
+	}
+
+	test("few")
+	SetPluralRules(CLDRPluralRules{})
+	test("two")
+	SetPluralRules(XTextPluralRules{})
+	test("few")
+}
+
+func TestICUPluralDecimal(t *testing.T) {
+	test := func(number interface{}, expectedSkeleton string, expectedDecimal string) {
+		ops, err := PluralOperands(number)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		skeleton, decimal := icuPluralDecimal(ops)
+		if skeleton != expectedSkeleton || decimal != expectedDecimal {
+			t.Errorf("%v: %v %v != %v %v\n", number, skeleton, decimal, expectedSkeleton, expectedDecimal)
+		}
+	}
+
+	test(1, "precision-integer", "1")
+	test("1.50", ".00", "1.50")
+	test("1.2c6", "compact-short precision-unlimited", "1200000")
+	// The digits are not reduced like I and F.
+	test("123456789012345678901", "precision-integer", "123456789012345678901")
+	test("0.9234567890123456789", ".0000000000000000000", "0.9234567890123456789")
+
+	// Operands not from PluralOperands are reconstructed from I and F.
+	skeleton, decimal := icuPluralDecimal(Operands{I: 1, V: 2, W: 1, F: 5, T: 5})
+	if skeleton != ".00" || decimal != "1.05" {
+		t.Errorf("%v %v != .00 1.05\n", skeleton, decimal)
+	}
+}
@@ -45,6 +45,26 @@ func TestCardinal(t *testing.T) {
 	test("ja", 2, "other")
 	test("ja", 3, "other")
 	test("ja", 4, "other")
+
+	// golang.org/x/text/feature/plural ignores the compact exponent.
+	test("fr", "1.2c6", "other")
+	test("fr", 1000000, "other")
+
+	// Compact numbers with CLDRPluralRules.
+	defer SetPluralRules(XTextPluralRules{})
+	SetPluralRules(CLDRPluralRules{})
+	test("fr", 1, "one")
+	test("fr", "1c3", "other")
+	test("fr", "1c6", "many")
+	test("fr", "1.2c6", "many")
+	test("fr", "1.2e6", "many")
+	test("fr", 1000000, "many")
+	test("fr", 1200000, "other")
+	test("fr", "1000000.0", "other")
+	test("es", "2c6", "many")
+	test("it", "2c6", "many")
+	test("pt-PT", "2c6", "many")
+	test("en", "1.2c6", "other")
 }
 
 func TestOrdinal(t *testing.T) {
@@ -0,0 +1,57 @@
+package messageformat
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// ArgumentFormatter formats value of a custom argument type.
+// style is the verbatim style text of the argument,
+// for example "USD" in `{price, money, USD}`.
+// style is empty if the argument has no style.
+type ArgumentFormatter func(tag language.Tag, style string, value interface{}) (string, error)
+
+var (
+	argumentFormattersMutex sync.RWMutex
+	argumentFormatters      = make(map[string]ArgumentFormatter)
+)
+
+// RegisterArgumentType makes the argument type name available to
+// Parse, FormatNamed, FormatPositional and TemplateRuntimeFunc.
+// If RegisterArgumentType is called twice with the same name,
+// or name is a builtin argument type, or f is nil, it panics.
+// It is intended to be called from the init function of a package.
+func RegisterArgumentType(name string, f ArgumentFormatter) {
+	argumentFormattersMutex.Lock()
+	defer argumentFormattersMutex.Unlock()
+
+	if f == nil {
+		panic("messageformat: RegisterArgumentType formatter is nil")
+	}
+	if isBuiltinArgumentType(name) {
+		panic("messageformat: RegisterArgumentType builtin type " + name)
+	}
+	if _, ok := argumentFormatters[name]; ok {
+		panic("messageformat: RegisterArgumentType called twice for " + name)
+	}
+	argumentFormatters[name] = f
+}
+
+func lookupArgumentFormatter(name string) (f ArgumentFormatter, ok bool) {
+	argumentFormattersMutex.RLock()
+	defer argumentFormattersMutex.RUnlock()
+
+	f, ok = argumentFormatters[name]
+	return
+}
+
+func formatCustomValue(tag language.Tag, typ string, style string, value interface{}) (out string, err error) {
+	f, ok := lookupArgumentFormatter(typ)
+	if !ok {
+		err = fmt.Errorf("unknown argument type: %v", typ)
+		return
+	}
+	return f(tag, style, value)
+}
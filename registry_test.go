@@ -0,0 +1,52 @@
+package messageformat
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func init() {
+	RegisterArgumentType("money", func(tag language.Tag, style string, value interface{}) (string, error) {
+		if style == "" {
+			style = "USD"
+		}
+		return fmt.Sprintf("%v %v (%v)", style, value, tag), nil
+	})
+}
+
+func TestRegisterArgumentType(t *testing.T) {
+	test := func(name string, f ArgumentFormatter, expected string) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Errorf("%v: expected panic\n", name)
+			} else if r != expected {
+				t.Errorf("%v: %v != %v\n", name, r, expected)
+			}
+		}()
+		RegisterArgumentType(name, f)
+	}
+
+	f := func(tag language.Tag, style string, value interface{}) (string, error) {
+		return "", nil
+	}
+	test("money", f, "messageformat: RegisterArgumentType called twice for money")
+	test("plural", f, "messageformat: RegisterArgumentType builtin type plural")
+	test("filesize", nil, "messageformat: RegisterArgumentType formatter is nil")
+}
+
+func TestFormatCustomValue(t *testing.T) {
+	out, err := formatCustomValue(language.Make("en"), "money", "EUR", 42)
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if out != "EUR 42 (en)" {
+		t.Errorf("%v != %v\n", out, "EUR 42 (en)")
+	}
+
+	_, err = formatCustomValue(language.Make("en"), "unknown", "", 42)
+	if err == nil || err.Error() != "unknown argument type: unknown" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
@@ -0,0 +1,113 @@
+package messageformat
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+type relativeTimeStyle struct {
+	Style   icu4c.RelativeTimeStyle
+	Numeric bool
+}
+
+// parseRelativeTimeStyle parses the style of a relativetime argument.
+// The default is long and numeric.
+func parseRelativeTimeStyle(style string) (out relativeTimeStyle, err error) {
+	out = relativeTimeStyle{
+		Style:   icu4c.RelativeTimeStyleLong,
+		Numeric: true,
+	}
+	for _, word := range strings.Fields(style) {
+		switch word {
+		case "long":
+			out.Style = icu4c.RelativeTimeStyleLong
+		case "short":
+			out.Style = icu4c.RelativeTimeStyleShort
+		case "narrow":
+			out.Style = icu4c.RelativeTimeStyleNarrow
+		case "numeric":
+			out.Numeric = true
+		case "auto":
+			out.Numeric = false
+		default:
+			err = fmt.Errorf("unexpected style: %v", word)
+			return
+		}
+	}
+	return
+}
+
+// relativeTimeUnits are the units of relative times from the largest,
+// with their approximate durations.
+var relativeTimeUnits = []struct {
+	Unit     icu4c.RelativeTimeUnit
+	Duration time.Duration
+}{
+	{icu4c.RelativeTimeUnitYear, 365 * 24 * time.Hour},
+	{icu4c.RelativeTimeUnitMonth, 30 * 24 * time.Hour},
+	{icu4c.RelativeTimeUnitWeek, 7 * 24 * time.Hour},
+	{icu4c.RelativeTimeUnitDay, 24 * time.Hour},
+	{icu4c.RelativeTimeUnitHour, time.Hour},
+	{icu4c.RelativeTimeUnitMinute, time.Minute},
+	{icu4c.RelativeTimeUnitSecond, time.Second},
+}
+
+// relativeTimeOffset expresses d in the largest unit that d is at least 1 of,
+// rounded to the nearest integer.
+// For example, 36 hours is 2 days.
+func relativeTimeOffset(d time.Duration) (unit icu4c.RelativeTimeUnit, offset float64) {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+
+	u := relativeTimeUnits[len(relativeTimeUnits)-1]
+	for _, candidate := range relativeTimeUnits {
+		if abs >= candidate.Duration {
+			u = candidate
+			break
+		}
+	}
+
+	offset = math.Round(float64(d) / float64(u.Duration))
+	// Avoid -0, which is in the past.
+	if offset == 0 {
+		offset = 0
+	}
+	return u.Unit, offset
+}
+
+// relativeTimeValue converts value to the duration from now.
+// A time.Time is relative to now, while a time.Duration is already relative.
+func relativeTimeValue(value interface{}, now func() time.Time) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case *time.Duration:
+		if v != nil {
+			return *v, true
+		}
+	case time.Time:
+		return v.Sub(now()), true
+	case *time.Time:
+		if v != nil {
+			return v.Sub(now()), true
+		}
+	}
+	return 0, false
+}
+
+func formatRelativeTime(tag language.Tag, style string, d time.Duration) (out string, err error) {
+	s, err := parseRelativeTimeStyle(style)
+	if err != nil {
+		return
+	}
+	unit, offset := relativeTimeOffset(d)
+	return icu4c.FormatRelativeTime(tag, s.Style, s.Numeric, unit, offset)
+}
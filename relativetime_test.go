@@ -0,0 +1,51 @@
+package messageformat
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+func TestRelativeTimeOffset(t *testing.T) {
+	test := func(d time.Duration, expectedUnit icu4c.RelativeTimeUnit, expectedOffset float64) {
+		unit, offset := relativeTimeOffset(d)
+		if unit != expectedUnit || offset != expectedOffset || math.Signbit(offset) != math.Signbit(expectedOffset) {
+			t.Errorf("%v: %v %v != %v %v\n", d, unit, offset, expectedUnit, expectedOffset)
+		}
+	}
+
+	test(0, icu4c.RelativeTimeUnitSecond, 0)
+	test(-400*time.Millisecond, icu4c.RelativeTimeUnitSecond, 0)
+	test(45*time.Second, icu4c.RelativeTimeUnitSecond, 45)
+	test(-90*time.Second, icu4c.RelativeTimeUnitMinute, -2)
+	test(2*time.Hour, icu4c.RelativeTimeUnitHour, 2)
+	test(-36*time.Hour, icu4c.RelativeTimeUnitDay, -2)
+	test(-24*time.Hour, icu4c.RelativeTimeUnitDay, -1)
+	test(10*24*time.Hour, icu4c.RelativeTimeUnitWeek, 1)
+	test(-45*24*time.Hour, icu4c.RelativeTimeUnitMonth, -2)
+	test(800*24*time.Hour, icu4c.RelativeTimeUnitYear, 2)
+}
+
+func TestParseRelativeTimeStyle(t *testing.T) {
+	test := func(style string, expected relativeTimeStyle) {
+		actual, err := parseRelativeTimeStyle(style)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%q: %v != %v\n", style, actual, expected)
+		}
+	}
+
+	test("", relativeTimeStyle{Style: icu4c.RelativeTimeStyleLong, Numeric: true})
+	test("short", relativeTimeStyle{Style: icu4c.RelativeTimeStyleShort, Numeric: true})
+	test("auto", relativeTimeStyle{Style: icu4c.RelativeTimeStyleLong, Numeric: false})
+	test("narrow  auto", relativeTimeStyle{Style: icu4c.RelativeTimeStyleNarrow, Numeric: false})
+	test("auto numeric", relativeTimeStyle{Style: icu4c.RelativeTimeStyleLong, Numeric: true})
+
+	_, err := parseRelativeTimeStyle("long always")
+	if err == nil || err.Error() != "unexpected style: always" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
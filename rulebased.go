@@ -0,0 +1,42 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+// parseRuleSet parses the style of spellout and ordinal arguments,
+// which is empty or a rule set of RuleBasedNumberFormat, such as %spellout-ordinal.
+func parseRuleSet(style string) (string, error) {
+	if style == "" || isRuleSet(style) {
+		return style, nil
+	}
+	return "", fmt.Errorf("unexpected style: %v", style)
+}
+
+func isRuleSet(s string) bool {
+	return strings.HasPrefix(s, "%") && len(s) > 1 && !strings.ContainsAny(s, " \t\r\n")
+}
+
+func ruleBasedNumberStyle(typ string) icu4c.RuleBasedNumberStyle {
+	switch typ {
+	case "spellout":
+		return icu4c.RuleBasedNumberStyleSpellout
+	case "ordinal":
+		return icu4c.RuleBasedNumberStyleOrdinal
+	case "duration":
+		return icu4c.RuleBasedNumberStyleDuration
+	default:
+		panic(fmt.Errorf("unexpected rule-based number type: %v", typ))
+	}
+}
+
+// formatRuleBasedNumber formats decimal with RuleBasedNumberFormat of typ,
+// which is spellout, ordinal or duration.
+func formatRuleBasedNumber(tag language.Tag, typ string, ruleSet string, decimal string) (out string, err error) {
+	return icu4c.FormatRuleBasedNumber(tag, ruleBasedNumberStyle(typ), ruleSet, decimal)
+}
@@ -102,6 +102,155 @@ func TemplateRuntimeFunc(typ string, args ...interface{}) interface{} {
 			panic(fmt.Errorf("messageformat: failed to format date time: %w", err))
 		}
 
+		return out
+	case "dateinterval":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		tag := language.Make(tagStr)
+		tz := icu4c.TZName("UTC")
+		interval, ok := dateIntervalValue(value)
+		if !ok {
+			panic(fmt.Errorf("expected %v to be DateInterval", value))
+		}
+		skeleton, err := dateIntervalSkeleton(styleStr)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format date interval: %w", err))
+		}
+		out, err := icu4c.FormatDateInterval(tag, tz, skeleton, interval.Start, interval.End)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format date interval: %w", err))
+		}
+
+		return out
+	case "relativetime":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		tag := language.Make(tagStr)
+		d, ok := relativeTimeValue(value, time.Now)
+		if !ok {
+			panic(fmt.Errorf("expected %v to be time.Duration or time.Time", value))
+		}
+		out, err := formatRelativeTime(tag, styleStr, d)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format relative time: %w", err))
+		}
+
+		return out
+	case "list":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		tag := language.Make(tagStr)
+		items, ok, err := listItems(tag, value)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format list: %w", err))
+		}
+		if !ok {
+			panic(fmt.Errorf("expected %v to be []string or []interface{}", value))
+		}
+		out, err := formatList(tag, styleStr, items)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format list: %w", err))
+		}
+
+		return out
+	case "number":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		decimal, precision, err := numberDecimal(value)
+		if err != nil {
+			panic(fmt.Errorf("expected %v to be a number", value))
+		}
+		out, err := formatNumber(language.Make(tagStr), styleStr, decimal, precision)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format number: %w", err))
+		}
+
+		return out
+	case "currency":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		c, ok := currencyValue(value)
+		if !ok {
+			panic(fmt.Errorf("expected %v to be Currency", value))
+		}
+		out, err := formatCurrency(language.Make(tagStr), styleStr, *c)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format currency: %w", err))
+		}
+
+		return out
+	case "duration":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		out, ok, err := formatDurationArgument(language.Make(tagStr), styleStr, value)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format duration: %w", err))
+		}
+		if !ok {
+			panic(fmt.Errorf("expected %v to be time.Duration or a number", value))
+		}
+
+		return out
+	case "spellout", "ordinal":
+		tagStr := args[0].(string)
+		styleStr := args[1].(string)
+		value := args[2]
+
+		if value == nil {
+			return ""
+		}
+		decimal, _, err := numberDecimal(value)
+		if err != nil {
+			panic(fmt.Errorf("expected %v to be a number", value))
+		}
+		out, err := formatRuleBasedNumber(language.Make(tagStr), typ, styleStr, decimal)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format %v: %w", typ, err))
+		}
+
+		return out
+	case "custom":
+		customType := args[0].(string)
+		tag := args[1].(string)
+		style := args[2].(string)
+		value := args[3]
+		if value == nil {
+			return ""
+		}
+		out, err := formatCustomValue(language.Make(tag), customType, style, value)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to format %v: %w", customType, err))
+		}
 		return out
 	case "select":
 		value := args[0]
@@ -114,6 +263,23 @@ func TemplateRuntimeFunc(typ string, args ...interface{}) interface{} {
 		}
 		keyword := args[1].(string)
 		return valueString == keyword
+	case "choice":
+		value := args[0]
+		limitStr := args[1].(string)
+		strict := args[2].(bool)
+
+		if value == nil {
+			value = 0
+		}
+		number, err := choiceNumber(value)
+		if err != nil {
+			panic(fmt.Errorf("expected %v to be a number", value))
+		}
+		limit, err := strconv.ParseFloat(limitStr, 64)
+		if err != nil {
+			panic(err)
+		}
+		return choiceBelow(number, limit, strict)
 	case "plural":
 		tag := args[0].(string)
 		offset := args[1].(int)
@@ -184,7 +350,33 @@ func TemplateRuntimeFunc(typ string, args ...interface{}) interface{} {
 		if err != nil {
 			panic(fmt.Errorf("messageformat: failed to cast offset value to string: %w", err))
 		}
+		if len(args) > 2 && isDecimalString(offsetValueString) {
+			tag := args[2].(string)
+			offsetValueString, err = localizeDigits(language.Make(tag), offsetValueString)
+			if err != nil {
+				panic(fmt.Errorf("messageformat: failed to localize digits: %w", err))
+			}
+		}
 		return offsetValueString
+	case "bidi":
+		tag := language.Make(args[0].(string))
+		value := args[1]
+		return bidiIsolateValue(tag, value)
+	case "none":
+		tag := args[0].(string)
+		value := args[1]
+		if !isNumber(value) {
+			return value
+		}
+		valueString, err := formatValue(value)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to cast value to string: %w", err))
+		}
+		valueString, err = localizeDigits(language.Make(tag), valueString)
+		if err != nil {
+			panic(fmt.Errorf("messageformat: failed to localize digits: %w", err))
+		}
+		return valueString
 	default:
 		panic("messageformat: unexpected argument type: " + typ)
 	}
@@ -210,7 +402,17 @@ func IsEmptyParseTree(tree *templateparse.Tree) bool {
 // This is the recommended way to use messageformat with html/template
 // where you can include HTML in your translation.
 func FormatTemplateParseTree(tag language.Tag, pattern string) (tree *templateparse.Tree, err error) {
-	nodes, err := Parse(pattern)
+	return Options{}.FormatTemplateParseTree(tag, pattern)
+}
+
+// FormatTemplateParseTree turns pattern into a text/template/parse.Tree.
+func (o Options) FormatTemplateParseTree(tag language.Tag, pattern string) (tree *templateparse.Tree, err error) {
+	nodes, err := o.Parse(pattern)
+	if err != nil {
+		return
+	}
+
+	tag, err = o.languageTag(tag)
 	if err != nil {
 		return
 	}
@@ -221,8 +423,9 @@ func FormatTemplateParseTree(tag language.Tag, pattern string) (tree *templatepa
 	}
 
 	formatter := &templateParseTreeFormatter{
-		Tree: parseTree,
-		Tag:  tag,
+		Tree:    parseTree,
+		Tag:     tag,
+		Options: o,
 	}
 
 	err = formatter.Format(formatter.Tree.Root, nodes, nil)
@@ -235,8 +438,9 @@ func FormatTemplateParseTree(tag language.Tag, pattern string) (tree *templatepa
 }
 
 type templateParseTreeFormatter struct {
-	Tree *templateparse.Tree
-	Tag  language.Tag
+	Tree    *templateparse.Tree
+	Tag     language.Tag
+	Options Options
 }
 
 func (f *templateParseTreeFormatter) Format(root *templateparse.ListNode, nodes []Node, argOffset *argumentOffset) (err error) {
@@ -252,12 +456,32 @@ func (f *templateParseTreeFormatter) Format(root *templateparse.ListNode, nodes
 			err = f.FormatTimeArgNode(root, node)
 		case DatetimeArgNode:
 			err = f.FormatDatetimeArgNode(root, node)
+		case DateIntervalArgNode:
+			err = f.FormatDateIntervalArgNode(root, node)
+		case RelativeTimeArgNode:
+			err = f.FormatRelativeTimeArgNode(root, node)
+		case ListArgNode:
+			err = f.FormatListArgNode(root, node)
+		case NumberArgNode:
+			err = f.FormatNumberArgNode(root, node)
+		case CurrencyArgNode:
+			err = f.FormatCurrencyArgNode(root, node)
+		case DurationArgNode:
+			err = f.FormatDurationArgNode(root, node)
+		case RuleBasedNumberArgNode:
+			err = f.FormatRuleBasedNumberArgNode(root, node)
+		case CustomArgNode:
+			err = f.FormatCustomArgNode(root, node)
 		case SelectArgNode:
 			err = f.FormatSelectArgNode(root, node)
 		case PluralArgNode:
 			err = f.FormatPluralArgNode(root, node)
+		case ChoiceArgNode:
+			err = f.FormatChoiceArgNode(root, node)
 		case PoundNode:
 			err = f.FormatPoundNode(root, argOffset)
+		case MarkupNode:
+			err = f.FormatMarkupNode(root, node, argOffset)
 		}
 		if err != nil {
 			return
@@ -274,142 +498,253 @@ func (f *templateParseTreeFormatter) FormatTextNode(root *templateparse.ListNode
 	return
 }
 
-func (f *templateParseTreeFormatter) FormatNoneArgNode(root *templateparse.ListNode, node NoneArgNode) (err error) {
+// appendArgAction appends the action `{{args}}` outputting an argument.
+// With Options.BidiIsolation, the output is piped to `__messageformat__ "bidi" tag`,
+// which isolates it from the surrounding text like FormatNamed does.
+func (f *templateParseTreeFormatter) appendArgAction(root *templateparse.ListNode, args []templateparse.Node) {
+	cmds := []*templateparse.CommandNode{
+		&templateparse.CommandNode{
+			NodeType: templateparse.NodeCommand,
+			Args:     args,
+		},
+	}
+	if f.Options.BidiIsolation {
+		cmds = append(cmds, &templateparse.CommandNode{
+			NodeType: templateparse.NodeCommand,
+			Args: []templateparse.Node{
+				&templateparse.IdentifierNode{
+					NodeType: templateparse.NodeIdentifier,
+					Ident:    TemplateRuntimeFuncName,
+				},
+				&templateparse.StringNode{
+					NodeType: templateparse.NodeString,
+					Quoted:   strconv.Quote("bidi"),
+					Text:     "bidi",
+				},
+				&templateparse.StringNode{
+					NodeType: templateparse.NodeString,
+					Quoted:   strconv.Quote(f.Tag.String()),
+					Text:     f.Tag.String(),
+				},
+			},
+		})
+	}
 	root.Nodes = append(root.Nodes, &templateparse.ActionNode{
 		NodeType: templateparse.NodeAction,
 		Pipe: &templateparse.PipeNode{
 			NodeType: templateparse.NodePipe,
-			Cmds: []*templateparse.CommandNode{
-				&templateparse.CommandNode{
-					NodeType: templateparse.NodeCommand,
-					Args: []templateparse.Node{
-						&templateparse.FieldNode{
-							NodeType: templateparse.NodeField,
-							Ident:    []string{node.Arg.Name},
-						},
-					},
-				},
-			},
+			Cmds:     cmds,
 		},
 	})
+}
+
+func (f *templateParseTreeFormatter) FormatNoneArgNode(root *templateparse.ListNode, node NoneArgNode) (err error) {
+	args := []templateparse.Node{
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{node.Arg.Name},
+		},
+	}
+	// Numbers are displayed in the numbering system of the language at runtime.
+	if hasNumberingSystem(f.Tag) {
+		args = []templateparse.Node{
+			&templateparse.IdentifierNode{
+				NodeType: templateparse.NodeIdentifier,
+				Ident:    TemplateRuntimeFuncName,
+			},
+			&templateparse.StringNode{
+				NodeType: templateparse.NodeString,
+				Quoted:   strconv.Quote("none"),
+				Text:     "none",
+			},
+			&templateparse.StringNode{
+				NodeType: templateparse.NodeString,
+				Quoted:   strconv.Quote(f.Tag.String()),
+				Text:     f.Tag.String(),
+			},
+			args[0],
+		}
+	}
+
+	f.appendArgAction(root, args)
 	return
 }
 
 func (f *templateParseTreeFormatter) FormatDateArgNode(root *templateparse.ListNode, node DateArgNode) (err error) {
-	root.Nodes = append(root.Nodes, &templateparse.ActionNode{
-		NodeType: templateparse.NodeAction,
-		Pipe: &templateparse.PipeNode{
-			NodeType: templateparse.NodePipe,
-			Cmds: []*templateparse.CommandNode{
-				&templateparse.CommandNode{
-					NodeType: templateparse.NodeCommand,
-					Args: []templateparse.Node{
-						&templateparse.IdentifierNode{
-							NodeType: templateparse.NodeIdentifier,
-							Ident:    TemplateRuntimeFuncName,
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote("date"),
-							Text:     "date",
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote(f.Tag.String()),
-							Text:     f.Tag.String(),
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote(node.Style),
-							Text:     node.Style,
-						},
-						&templateparse.FieldNode{
-							NodeType: templateparse.NodeField,
-							Ident:    []string{node.Arg.Name},
-						},
-					},
-				},
-			},
+	f.appendArgAction(root, []templateparse.Node{
+		&templateparse.IdentifierNode{
+			NodeType: templateparse.NodeIdentifier,
+			Ident:    TemplateRuntimeFuncName,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote("date"),
+			Text:     "date",
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(f.Tag.String()),
+			Text:     f.Tag.String(),
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(node.Style),
+			Text:     node.Style,
+		},
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{node.Arg.Name},
 		},
 	})
 	return
 }
 
 func (f *templateParseTreeFormatter) FormatTimeArgNode(root *templateparse.ListNode, node TimeArgNode) (err error) {
-	root.Nodes = append(root.Nodes, &templateparse.ActionNode{
-		NodeType: templateparse.NodeAction,
-		Pipe: &templateparse.PipeNode{
-			NodeType: templateparse.NodePipe,
-			Cmds: []*templateparse.CommandNode{
-				&templateparse.CommandNode{
-					NodeType: templateparse.NodeCommand,
-					Args: []templateparse.Node{
-						&templateparse.IdentifierNode{
-							NodeType: templateparse.NodeIdentifier,
-							Ident:    TemplateRuntimeFuncName,
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote("time"),
-							Text:     "time",
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote(f.Tag.String()),
-							Text:     f.Tag.String(),
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote(node.Style),
-							Text:     node.Style,
-						},
-						&templateparse.FieldNode{
-							NodeType: templateparse.NodeField,
-							Ident:    []string{node.Arg.Name},
-						},
-					},
-				},
-			},
+	f.appendArgAction(root, []templateparse.Node{
+		&templateparse.IdentifierNode{
+			NodeType: templateparse.NodeIdentifier,
+			Ident:    TemplateRuntimeFuncName,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote("time"),
+			Text:     "time",
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(f.Tag.String()),
+			Text:     f.Tag.String(),
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(node.Style),
+			Text:     node.Style,
+		},
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{node.Arg.Name},
 		},
 	})
 	return
 }
 
 func (f *templateParseTreeFormatter) FormatDatetimeArgNode(root *templateparse.ListNode, node DatetimeArgNode) (err error) {
-	root.Nodes = append(root.Nodes, &templateparse.ActionNode{
-		NodeType: templateparse.NodeAction,
-		Pipe: &templateparse.PipeNode{
-			NodeType: templateparse.NodePipe,
-			Cmds: []*templateparse.CommandNode{
-				&templateparse.CommandNode{
-					NodeType: templateparse.NodeCommand,
-					Args: []templateparse.Node{
-						&templateparse.IdentifierNode{
-							NodeType: templateparse.NodeIdentifier,
-							Ident:    TemplateRuntimeFuncName,
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote("datetime"),
-							Text:     "datetime",
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote(f.Tag.String()),
-							Text:     f.Tag.String(),
-						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote(node.Style),
-							Text:     node.Style,
-						},
-						&templateparse.FieldNode{
-							NodeType: templateparse.NodeField,
-							Ident:    []string{node.Arg.Name},
-						},
-					},
-				},
-			},
+	f.appendArgAction(root, []templateparse.Node{
+		&templateparse.IdentifierNode{
+			NodeType: templateparse.NodeIdentifier,
+			Ident:    TemplateRuntimeFuncName,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote("datetime"),
+			Text:     "datetime",
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(f.Tag.String()),
+			Text:     f.Tag.String(),
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(node.Style),
+			Text:     node.Style,
+		},
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{node.Arg.Name},
+		},
+	})
+	return
+}
+
+// formatRuntimeArgAction appends the action `{{__messageformat__ typ tag style .Arg}}`,
+// which formats the argument of typ at runtime.
+func (f *templateParseTreeFormatter) formatRuntimeArgAction(root *templateparse.ListNode, typ string, arg Argument, style string) (err error) {
+	f.appendArgAction(root, []templateparse.Node{
+		&templateparse.IdentifierNode{
+			NodeType: templateparse.NodeIdentifier,
+			Ident:    TemplateRuntimeFuncName,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(typ),
+			Text:     typ,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(f.Tag.String()),
+			Text:     f.Tag.String(),
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(style),
+			Text:     style,
+		},
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{arg.Name},
+		},
+	})
+	return
+}
+
+func (f *templateParseTreeFormatter) FormatDateIntervalArgNode(root *templateparse.ListNode, node DateIntervalArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, "dateinterval", node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatRelativeTimeArgNode(root *templateparse.ListNode, node RelativeTimeArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, "relativetime", node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatListArgNode(root *templateparse.ListNode, node ListArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, "list", node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatNumberArgNode(root *templateparse.ListNode, node NumberArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, "number", node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatCurrencyArgNode(root *templateparse.ListNode, node CurrencyArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, "currency", node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatDurationArgNode(root *templateparse.ListNode, node DurationArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, "duration", node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatRuleBasedNumberArgNode(root *templateparse.ListNode, node RuleBasedNumberArgNode) (err error) {
+	return f.formatRuntimeArgAction(root, node.Type, node.Arg, node.Style)
+}
+
+func (f *templateParseTreeFormatter) FormatCustomArgNode(root *templateparse.ListNode, node CustomArgNode) (err error) {
+	f.appendArgAction(root, []templateparse.Node{
+		&templateparse.IdentifierNode{
+			NodeType: templateparse.NodeIdentifier,
+			Ident:    TemplateRuntimeFuncName,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote("custom"),
+			Text:     "custom",
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(node.Type),
+			Text:     node.Type,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(f.Tag.String()),
+			Text:     f.Tag.String(),
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(node.Style),
+			Text:     node.Style,
+		},
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{node.Arg.Name},
 		},
 	})
 	return
@@ -501,6 +836,78 @@ func (f *templateParseTreeFormatter) FormatSelectArgNode(root *templateparse.Lis
 	return
 }
 
+func (f *templateParseTreeFormatter) FormatChoiceArgNode(root *templateparse.ListNode, node ChoiceArgNode) (err error) {
+	// The clause before the first limit the value is below is selected,
+	// or else the last clause.
+	currRoot := root
+	for i := 1; i < len(node.Clauses); i++ {
+		limit := strconv.FormatFloat(node.Clauses[i].Limit, 'g', -1, 64)
+		ifNode := &templateparse.IfNode{
+			BranchNode: templateparse.BranchNode{
+				NodeType: templateparse.NodeIf,
+				// This is the if condition
+				Pipe: &templateparse.PipeNode{
+					NodeType: templateparse.NodePipe,
+					Cmds: []*templateparse.CommandNode{
+						&templateparse.CommandNode{
+							NodeType: templateparse.NodeCommand,
+							Args: []templateparse.Node{
+								&templateparse.IdentifierNode{
+									NodeType: templateparse.NodeIdentifier,
+									Ident:    TemplateRuntimeFuncName,
+								},
+								&templateparse.StringNode{
+									NodeType: templateparse.NodeString,
+									Quoted:   strconv.Quote("choice"),
+									Text:     "choice",
+								},
+								&templateparse.FieldNode{
+									NodeType: templateparse.NodeField,
+									Ident:    []string{node.Arg.Name},
+								},
+								&templateparse.StringNode{
+									NodeType: templateparse.NodeString,
+									Quoted:   strconv.Quote(limit),
+									Text:     limit,
+								},
+								&templateparse.BoolNode{
+									NodeType: templateparse.NodeBool,
+									True:     node.Clauses[i].Strict,
+								},
+							},
+						},
+					},
+				},
+				List: &templateparse.ListNode{
+					NodeType: templateparse.NodeList,
+					Nodes:    []templateparse.Node{},
+				},
+				ElseList: &templateparse.ListNode{
+					NodeType: templateparse.NodeList,
+					Nodes:    []templateparse.Node{},
+				},
+			},
+		}
+
+		// Recursively format the if body.
+		err = f.Format(ifNode.BranchNode.List, node.Clauses[i-1].Nodes, nil)
+		if err != nil {
+			return
+		}
+
+		currRoot.Nodes = append(currRoot.Nodes, ifNode)
+		currRoot = ifNode.BranchNode.ElseList
+	}
+
+	// Construct the final else.
+	err = f.Format(currRoot, node.Clauses[len(node.Clauses)-1].Nodes, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
 func (f *templateParseTreeFormatter) FormatPluralArgNode(root *templateparse.ListNode, node PluralArgNode) (err error) {
 	var nonOtherClauses []PluralClause
 	var otherClause *PluralClause
@@ -600,34 +1007,69 @@ func (f *templateParseTreeFormatter) FormatPluralArgNode(root *templateparse.Lis
 }
 
 func (f *templateParseTreeFormatter) FormatPoundNode(root *templateparse.ListNode, argOffset *argumentOffset) (err error) {
-	root.Nodes = append(root.Nodes, &templateparse.ActionNode{
-		NodeType: templateparse.NodeAction,
+	args := []templateparse.Node{
+		&templateparse.IdentifierNode{
+			NodeType: templateparse.NodeIdentifier,
+			Ident:    TemplateRuntimeFuncName,
+		},
+		&templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote("pound"),
+			Text:     "pound",
+		},
+		&templateparse.FieldNode{
+			NodeType: templateparse.NodeField,
+			Ident:    []string{argOffset.Name},
+		},
+		makeNumberNode(argOffset.Offset),
+	}
+	// The language is needed only for its numbering system.
+	if hasNumberingSystem(f.Tag) {
+		args = append(args, &templateparse.StringNode{
+			NodeType: templateparse.NodeString,
+			Quoted:   strconv.Quote(f.Tag.String()),
+			Text:     f.Tag.String(),
+		})
+	}
+
+	f.appendArgAction(root, args)
+	return
+}
+
+func (f *templateParseTreeFormatter) FormatMarkupNode(root *templateparse.ListNode, node MarkupNode, argOffset *argumentOffset) (err error) {
+	markupTemplate, ok := f.Options.MarkupTemplates[node.Name]
+	if !ok {
+		return f.Format(root, node.Nodes, argOffset)
+	}
+
+	root.Nodes = append(root.Nodes, makeDotTemplateNode(markupTemplate.Open))
+	err = f.Format(root, node.Nodes, argOffset)
+	if err != nil {
+		return
+	}
+	root.Nodes = append(root.Nodes, makeDotTemplateNode(markupTemplate.Close))
+	return
+}
+
+// makeDotTemplateNode makes {{template "name" .}}.
+func makeDotTemplateNode(name string) *templateparse.TemplateNode {
+	return &templateparse.TemplateNode{
+		NodeType: templateparse.NodeTemplate,
+		Name:     name,
 		Pipe: &templateparse.PipeNode{
 			NodeType: templateparse.NodePipe,
 			Cmds: []*templateparse.CommandNode{
 				&templateparse.CommandNode{
 					NodeType: templateparse.NodeCommand,
 					Args: []templateparse.Node{
-						&templateparse.IdentifierNode{
-							NodeType: templateparse.NodeIdentifier,
-							Ident:    TemplateRuntimeFuncName,
+						&templateparse.DotNode{
+							NodeType: templateparse.NodeDot,
 						},
-						&templateparse.StringNode{
-							NodeType: templateparse.NodeString,
-							Quoted:   strconv.Quote("pound"),
-							Text:     "pound",
-						},
-						&templateparse.FieldNode{
-							NodeType: templateparse.NodeField,
-							Ident:    []string{argOffset.Name},
-						},
-						makeNumberNode(argOffset.Offset),
 					},
 				},
 			},
 		},
-	})
-	return
+	}
 }
 
 func makeNumberNode(offset int) *templateparse.NumberNode {
@@ -0,0 +1,225 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package messageformat
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestFormatTemplateParseTreeDatetime(t *testing.T) {
+	en := language.Make("en")
+
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		tree, err := FormatTemplateParseTree(en, pattern)
+		if err != nil {
+			t.Errorf("failed to format html template: %v\n", err)
+		} else {
+			template := htmltemplate.New("main")
+			template.Funcs(htmltemplate.FuncMap{
+				TemplateRuntimeFuncName: TemplateRuntimeFunc,
+			})
+			template, err := template.AddParseTree("main", tree)
+			if err != nil {
+				t.Errorf("failed to add parse tree: %v\n", err)
+			} else {
+				var buf strings.Builder
+				err = template.Execute(&buf, args)
+				if err != nil {
+					t.Errorf("failed to execute: %v\n", err)
+				} else {
+					actual := buf.String()
+					if !normalizeICUSpacesEqual(actual, expected) {
+						t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+					}
+				}
+			}
+		}
+	}
+
+	// date arguments.
+	test("{T, date, short}", "11/10/09", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, date, medium}", "Nov 10, 2009", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, date, long}", "November 10, 2009", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, date, full}", "Tuesday, November 10, 2009", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, short}", "11:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, medium}", "11:00:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, long}", "11:00:00 PM UTC", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, full}", "11:00:00 PM Coordinated Universal Time", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, short}", "11/10/09, 11:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, medium}", "Nov 10, 2009, 11:00:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, long}", "November 10, 2009 at 11:00:00 PM UTC", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, full}", "Tuesday, November 10, 2009 at 11:00:00 PM Coordinated Universal Time", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestTemplateCalendar(t *testing.T) {
+	test := func(o Options, lang string, expected string) {
+		actual, ok := executeTemplateWithOptions(t, o, lang, "{T, date, full}", map[string]interface{}{
+			"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		})
+		if ok && actual != expected {
+			t.Errorf("%v %v: %q != %q\n", o.Calendar, lang, actual, expected)
+		}
+	}
+
+	test(Options{}, "ja-JP-u-ca-japanese", "平成21年11月10日火曜日")
+	test(Options{Calendar: "japanese"}, "ja-JP", "平成21年11月10日火曜日")
+	test(Options{Calendar: "hebrew"}, "en", "Tuesday, 23 Heshvan 5770")
+}
+
+func TestTemplateUnicodeExtensions(t *testing.T) {
+	args := map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		"N": 1234.5,
+		"C": 3,
+		"S": "123",
+	}
+	test := func(lang string, pattern string, expected string) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en-u-nu-arab", "{N}", "١٢٣٤٫٥")
+	test("en-u-nu-arab", "{C, plural, offset:1 other {# items}}", "٢ items")
+	test("en-u-nu-arab", "{S}", "123")
+	test("en-u-nu-arab-hc-h23", "{T, time, short}", "٢٣:٠٠")
+	test("en-US-u-hc-h23", "{T, datetime, short}", "11/10/09, 23:00")
+	test("en-US-u-fw-mon-hc-h23", "{T, time, short}", "23:00")
+	test("en", "{N} {C, plural, other {#}}", "1234.5 3")
+}
+
+func TestTemplateDateInterval(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	interval := DateInterval{
+		Start: time.Date(2025, time.January, 3, 9, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, time.January, 5, 17, 0, 0, 0, time.UTC),
+	}
+	test("en", "{R, dateinterval, ::yMMMd}", "Jan 3\u2009\u2013\u20095, 2025", map[string]interface{}{"R": interval})
+	test("de", "{R, dateinterval, long}", "3.\u20135. Januar 2025", map[string]interface{}{"R": &interval})
+	test("en", "Stay: {R, dateinterval, medium}", "Stay: ", map[string]interface{}{})
+}
+
+func TestTemplateRelativeTime(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{T, relativetime}", "3 days ago", map[string]interface{}{"T": -72 * time.Hour})
+	test("en", "{T, relativetime, narrow auto}", "yesterday", map[string]interface{}{"T": -24 * time.Hour})
+	// A time.Time is relative to the time when the template is executed.
+	test("en", "{T, relativetime}", "in 3 days", map[string]interface{}{"T": time.Now().Add(72 * time.Hour)})
+	test("en", "Seen {T, relativetime}", "Seen ", map[string]interface{}{})
+}
+
+func TestTemplateList(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{L, list}", "Alice, Bob, and Carol", map[string]interface{}{"L": []string{"Alice", "Bob", "Carol"}})
+	test("en", "{L, list, disjunction}", "1 or 2", map[string]interface{}{"L": []interface{}{1, 2}})
+	// The output is escaped like other values.
+	test("en", "{L, list}", "&lt;b&gt; and Bob", map[string]interface{}{"L": []string{"<b>", "Bob"}})
+	test("en", "Invite {L, list}", "Invite ", map[string]interface{}{})
+}
+
+func TestTemplateNumber(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{N, number}", "1,234.5", map[string]interface{}{"N": 1234.5})
+	test("en", "{N, number, ::unit/kilometer unit-width-short}", "5 km", map[string]interface{}{"N": 5})
+	test("en-US", "{N, number, ::unit/kilometer usage/road}", "3.1 mi", map[string]interface{}{"N": 5})
+	test("en", "{N, number}", "1.00", map[string]interface{}{"N": Number{Value: 1, MinimumFractionDigits: 2}})
+	test("en", "Total: {N, number}", "Total: ", map[string]interface{}{})
+}
+
+func TestTemplateCurrency(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{P, currency}", "€1,234.50", map[string]interface{}{"P": Currency{Value: "1234.5", Code: "EUR"}})
+	test("de", "{P, currency, name}", "1.234,50 Euro", map[string]interface{}{"P": Currency{Value: "1234.5", Code: "EUR"}})
+	test("en", "{N, number, ::currency/EUR}", "€1,234.50", map[string]interface{}{"N": "1234.5"})
+	test("en", "Total: {P, currency}", "Total: ", map[string]interface{}{})
+}
+
+func TestTemplateDuration(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	d := time.Hour + 5*time.Minute
+	test("en", "{D, duration}", "1 hr, 5 min", map[string]interface{}{"D": d})
+	test("en", "{D, duration, digital}", "1:05:00", map[string]interface{}{"D": d})
+	test("en", "Took {D, duration}", "Took ", map[string]interface{}{})
+	test("en", "{D, duration, %with-words}", "1 hour, 5 minutes, 0 seconds", map[string]interface{}{"D": 3900})
+}
+
+func TestTemplateRuleBasedNumber(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, lang, pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{N, spellout}", "twenty-one", map[string]interface{}{"N": 21})
+	test("en", "{N, ordinal}", "21st", map[string]interface{}{"N": 21})
+	test("en", "{N, spellout, %spellout-ordinal}", "twenty-first", map[string]interface{}{"N": 21})
+	test("en", "Rank {N, ordinal}", "Rank ", map[string]interface{}{})
+}
@@ -3,9 +3,11 @@ package messageformat
 import (
 	"fmt"
 	htmltemplate "html/template"
+	"math"
 	"strings"
 	"testing"
-	"time"
+	texttemplate "text/template"
+	templateparse "text/template/parse"
 
 	"golang.org/x/text/language"
 )
@@ -62,44 +64,6 @@ func TestFormatTemplateParseTree(t *testing.T) {
 		"ME":  "Jane",
 	})
 
-	// date arguments.
-	test("{T, date, short}", "11/10/09", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, date, medium}", "Nov 10, 2009", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, date, long}", "November 10, 2009", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, date, full}", "Tuesday, November 10, 2009", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, short}", "11:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, medium}", "11:00:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, long}", "11:00:00 PM UTC", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, full}", "11:00:00 PM Coordinated Universal Time", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, short}", "11/10/09, 11:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, medium}", "Nov 10, 2009, 11:00:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, long}", "November 10, 2009 at 11:00:00 PM UTC", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, full}", "Tuesday, November 10, 2009 at 11:00:00 PM Coordinated Universal Time", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-
 	// Simple select
 	test(`{GENDER, select,
 				male {He jumps over the lazy dog}
@@ -160,6 +124,11 @@ func TestFormatTemplateParseTree(t *testing.T) {
 	test(`{COUNT, plural, one{# cat} other{# cats}}`, "2 cats", map[string]interface{}{
 		"COUNT": 2,
 	})
+	// Compact notation is displayed as is.
+	test(`code {CODE}, {COUNT, plural, one{# cat} other{# cats}}`, "code 2e5, 1.2e6 cats", map[string]interface{}{
+		"CODE":  "2e5",
+		"COUNT": "1.2e6",
+	})
 
 	// plural with explicit value
 	test(`{COUNT, plural, =0{no cats} one{# cat} other{# cats}}`, "no cats", map[string]interface{}{
@@ -314,6 +283,11 @@ func TestFormatTemplateParseTree(t *testing.T) {
 		"GUEST":  guest,
 	})
 
+	// Custom argument type.
+	test("It costs {PRICE, money, <EUR>}", "It costs &lt;EUR&gt; 42 (en)", map[string]interface{}{
+		"PRICE": 42,
+	})
+
 	// HTML
 	test(`Hello <b>{NAME}</b>`, `Hello <b>John</b>`, map[string]interface{}{
 		"NAME": "John",
@@ -336,6 +310,23 @@ func TestFormatTemplateParseTree(t *testing.T) {
 	)
 }
 
+func TestTemplateChoice(t *testing.T) {
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		actual, ok := executeTemplate(t, "en", pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	pattern := "{N, choice, 0#no files|1#one file|1<{N} files}"
+	test(pattern, "no files", map[string]interface{}{"N": 0})
+	test(pattern, "one file", map[string]interface{}{"N": 1})
+	test(pattern, "2 files", map[string]interface{}{"N": 2})
+	test(pattern, "1.5 files", map[string]interface{}{"N": 1.5})
+	test(pattern, "no files", map[string]interface{}{})
+	test("{N, choice, 0#finite|∞#infinite}", "infinite", map[string]interface{}{"N": math.Inf(1)})
+}
+
 func TestTemplateUnknownArgument(t *testing.T) {
 	en := language.Make("en")
 	test := func(pattern string, expected string, args map[string]interface{}) {
@@ -371,6 +362,187 @@ func TestTemplateUnknownArgument(t *testing.T) {
 	test("Hello {T, datetime, short} Hello", "Hello  Hello", nil)
 	test("Hello {GENDER, select, male {he} female {she} other {they}}", "Hello they", nil)
 	test("Hello {COUNT, plural, one {# cat} other {# cats}}", "Hello 0 cats", nil)
+	test("Hello {PRICE, money} Hello", "Hello  Hello", nil)
+}
+
+func TestTemplateMarkup(t *testing.T) {
+	en := language.Make("en")
+	options := Options{
+		Markup: true,
+		MarkupTemplates: map[string]MarkupTemplate{
+			"link": MarkupTemplate{Open: "link_open", Close: "link_close"},
+		},
+	}
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		tree, err := options.FormatTemplateParseTree(en, pattern)
+		if err != nil {
+			t.Errorf("failed to format html template: %v\n", err)
+			return
+		}
+		template := htmltemplate.New("main")
+		template.Funcs(htmltemplate.FuncMap{
+			TemplateRuntimeFuncName: TemplateRuntimeFunc,
+		})
+		template = htmltemplate.Must(template.Parse(`{{define "link_open"}}<a href="{{.URL}}">{{end}}{{define "link_close"}}</a>{{end}}`))
+		template, err = template.AddParseTree("main", tree)
+		if err != nil {
+			t.Errorf("failed to add parse tree: %v\n", err)
+			return
+		}
+		var buf strings.Builder
+		err = template.ExecuteTemplate(&buf, "main", args)
+		if err != nil {
+			t.Errorf("failed to execute: %v\n", err)
+		} else if actual := buf.String(); actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	test("Visit <link>{NAME}</link>", `Visit <a href="https://example.com/?a=1&amp;b=2">&lt;John&gt;</a>`, map[string]interface{}{
+		"NAME": "<John>",
+		"URL":  "https://example.com/?a=1&b=2",
+	})
+	test("{COUNT, plural, other {<link>#</link> cats}}", `<a href="https://example.com">3</a> cats`, map[string]interface{}{
+		"COUNT": 3,
+		"URL":   "https://example.com",
+	})
+	// A tag without templates outputs its content.
+	test("<b>{NAME}</b>", `&lt;John&gt;`, map[string]interface{}{
+		"NAME": "<John>",
+	})
+}
+
+func TestTemplateBidiIsolation(t *testing.T) {
+	args := map[string]interface{}{
+		"NAME":  "<John>",
+		"COUNT": 3,
+		"PRICE": 42,
+		"HTML":  htmltemplate.HTML("<b>John</b>"),
+	}
+	test := func(options Options, pattern string, expected string) {
+		actual, ok := executeTemplateWithOptions(t, options, "he", pattern, args)
+		if ok && actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	isolate := Options{BidiIsolation: true}
+	test(Options{}, "שלום {NAME}!", "שלום &lt;John&gt;!")
+	test(isolate, "שלום {NAME}!", "שלום \u2068&lt;John&gt;\u2069!")
+	// Neutral values, such as numbers, are not isolated.
+	test(isolate, "{COUNT} הודעות", "3 הודעות")
+	test(isolate, "{COUNT, plural, other {# הודעות}}", "3 הודעות")
+	// Formatted arguments are isolated too.
+	test(isolate, "מחיר: {PRICE, money}", "מחיר: \u2068USD 42 (he)\u2069")
+	// The isolation is plain text, which is valid in attributes.
+	test(isolate, "<a title=\"{NAME}\">", "<a title=\"\u2068&lt;John&gt;\u2069\">")
+	// A value of a named string type keeps its type.
+	test(isolate, "{HTML}", "\u2068<b>John</b>\u2069")
+
+	// text/template outputs no HTML.
+	tree, err := isolate.FormatTemplateParseTree(language.Make("he"), "שלום {NAME}!")
+	if err != nil {
+		t.Fatalf("failed to format text template: %v\n", err)
+	}
+	template := texttemplate.New("main")
+	template.Funcs(texttemplate.FuncMap{
+		TemplateRuntimeFuncName: TemplateRuntimeFunc,
+	})
+	template, err = template.AddParseTree("main", tree)
+	if err != nil {
+		t.Fatalf("failed to add parse tree: %v\n", err)
+	}
+	var buf strings.Builder
+	err = template.Execute(&buf, args)
+	if err != nil {
+		t.Fatalf("failed to execute: %v\n", err)
+	}
+	expected := "שלום \u2068<John>\u2069!"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("%q != %q\n", actual, expected)
+	}
+}
+
+// executeTemplate formats pattern with FormatTemplateParseTree
+// and executes the template with data.
+// ok is false if any step fails, which is reported to t.
+func executeTemplate(t *testing.T, lang string, pattern string, data interface{}) (out string, ok bool) {
+	t.Helper()
+	return executeTemplateWithOptions(t, Options{}, lang, pattern, data)
+}
+
+func executeTemplateWithOptions(t *testing.T, o Options, lang string, pattern string, data interface{}) (out string, ok bool) {
+	t.Helper()
+	tree, err := o.FormatTemplateParseTree(language.Make(lang), pattern)
+	if err != nil {
+		t.Errorf("%v: failed to format html template: %v\n", pattern, err)
+		return
+	}
+	template := htmltemplate.New("main")
+	template.Funcs(htmltemplate.FuncMap{
+		TemplateRuntimeFuncName: TemplateRuntimeFunc,
+	})
+	template, err = template.AddParseTree("main", tree)
+	if err != nil {
+		t.Errorf("%v: failed to add parse tree: %v\n", pattern, err)
+		return
+	}
+	var buf strings.Builder
+	err = template.Execute(&buf, data)
+	if err != nil {
+		t.Errorf("%v: failed to execute: %v\n", pattern, err)
+		return
+	}
+	return buf.String(), true
+}
+
+func TestTemplateWithoutRuntimeFunc(t *testing.T) {
+	// Simple substitution does not need TemplateRuntimeFunc.
+	tree, err := FormatTemplateParseTree(language.Make("en"), "Hello {NAME}")
+	if err != nil {
+		t.Fatalf("err: %v\n", err)
+	}
+	template, err := htmltemplate.New("main").AddParseTree("main", tree)
+	if err != nil {
+		t.Fatalf("err: %v\n", err)
+	}
+	var buf strings.Builder
+	err = template.Execute(&buf, map[string]interface{}{
+		"NAME": "John",
+	})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if buf.String() != "Hello John" {
+		t.Errorf("%q != %q\n", buf.String(), "Hello John")
+	}
+
+	// The runtime func is needed only for the numbering system and bidi isolation.
+	test := func(o Options, lang string, expected bool) {
+		tree, err := o.FormatTemplateParseTree(language.Make(lang), "{NAME}")
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+			return
+		}
+		actual := false
+		for _, node := range tree.Root.Nodes {
+			action, ok := node.(*templateparse.ActionNode)
+			if !ok {
+				continue
+			}
+			for _, cmd := range action.Pipe.Cmds {
+				if ident, ok := cmd.Args[0].(*templateparse.IdentifierNode); ok && ident.Ident == TemplateRuntimeFuncName {
+					actual = true
+				}
+			}
+		}
+		if actual != expected {
+			t.Errorf("%v %v: %v != %v\n", o.BidiIsolation, lang, actual, expected)
+		}
+	}
+
+	test(Options{}, "en", false)
+	test(Options{}, "en-u-nu-arab", true)
+	test(Options{BidiIsolation: true}, "en", true)
 }
 
 func TestIsEmptyParseTree(t *testing.T) {
@@ -13,25 +13,189 @@ import (
 
 // FormatPositional parses pattern and format to string with a slice of args.
 func FormatPositional(tag language.Tag, pattern string, args ...interface{}) (out string, err error) {
+	return Options{}.FormatPositional(tag, pattern, args...)
+}
+
+// FormatNamed parses pattern and format to string with a map of args.
+func FormatNamed(tag language.Tag, pattern string, args map[string]interface{}) (out string, err error) {
+	return Options{}.FormatNamed(tag, pattern, args)
+}
+
+// FormatPositional parses pattern and format to string with a slice of args.
+func (o Options) FormatPositional(tag language.Tag, pattern string, args ...interface{}) (out string, err error) {
+	return o.FormatNamed(tag, pattern, positionalArgs(args))
+}
+
+// FormatNamed parses pattern and format to string with a map of args.
+func (o Options) FormatNamed(tag language.Tag, pattern string, args map[string]interface{}) (out string, err error) {
+	nodes, err := o.Parse(pattern)
+	if err != nil {
+		return
+	}
+
+	tag, err = o.languageTag(tag)
+	if err != nil {
+		return
+	}
+
+	formatter := &textFormatter{
+		Buf:     &strings.Builder{},
+		Tag:     tag,
+		Args:    args,
+		Options: o,
+	}
+
+	err = formatter.Format(nodes, nil)
+	if err != nil {
+		return
+	}
+
+	out = formatter.Buf.String()
+	return
+}
+
+func positionalArgs(args []interface{}) map[string]interface{} {
 	o := make(map[string]interface{})
 	for idx, val := range args {
 		name := strconv.Itoa(idx)
 		o[name] = val
 	}
-	return FormatNamed(tag, pattern, o)
+	return o
 }
 
-// FormatNamed parses pattern and format to string with a map of args.
-func FormatNamed(tag language.Tag, pattern string, args map[string]interface{}) (out string, err error) {
-	nodes, err := Parse(pattern)
+// PartKind tells where a Part comes from.
+type PartKind int
+
+const (
+	// PartKindLiteral is text written in the pattern.
+	PartKindLiteral PartKind = iota
+	// PartKindArgument is a non-numeric value of `{Argument}`.
+	PartKindArgument
+	// PartKindNumber is a numeric value of `{Argument}`,
+	// or the output of `{Argument, number [, style]}`.
+	PartKindNumber
+	// PartKindPound is the value of `#`.
+	PartKindPound
+	// PartKindDate is the output of `{Argument, date, style}`.
+	PartKindDate
+	// PartKindTime is the output of `{Argument, time, style}`.
+	PartKindTime
+	// PartKindDatetime is the output of `{Argument, datetime, style}`.
+	PartKindDatetime
+	// PartKindCustom is the output of `{Argument, Type, Style}`
+	// where Type is registered with RegisterArgumentType.
+	PartKindCustom
+	// PartKindMarkupStart is the start of a markup tag.
+	// Its Value is empty.
+	PartKindMarkupStart
+	// PartKindMarkupEnd is the end of a markup tag.
+	// Its Value is empty.
+	PartKindMarkupEnd
+	// PartKindDateInterval is the output of `{Argument, dateinterval, style}`.
+	PartKindDateInterval
+	// PartKindRelativeTime is the output of `{Argument, relativetime [, style]}`.
+	PartKindRelativeTime
+	// PartKindList is the output of `{Argument, list [, style]}`.
+	PartKindList
+	// PartKindCurrency is the output of `{Argument, currency [, style]}`.
+	PartKindCurrency
+	// PartKindDuration is the output of `{Argument, duration [, style]}`.
+	PartKindDuration
+	// PartKindSpellout is the output of `{Argument, spellout [, style]}`.
+	PartKindSpellout
+	// PartKindOrdinal is the output of `{Argument, ordinal [, style]}`.
+	PartKindOrdinal
+)
+
+func (k PartKind) String() string {
+	switch k {
+	case PartKindLiteral:
+		return "literal"
+	case PartKindArgument:
+		return "argument"
+	case PartKindNumber:
+		return "number"
+	case PartKindPound:
+		return "pound"
+	case PartKindDate:
+		return "date"
+	case PartKindTime:
+		return "time"
+	case PartKindDatetime:
+		return "datetime"
+	case PartKindCustom:
+		return "custom"
+	case PartKindMarkupStart:
+		return "markup-start"
+	case PartKindMarkupEnd:
+		return "markup-end"
+	case PartKindDateInterval:
+		return "dateinterval"
+	case PartKindRelativeTime:
+		return "relativetime"
+	case PartKindList:
+		return "list"
+	case PartKindCurrency:
+		return "currency"
+	case PartKindDuration:
+		return "duration"
+	case PartKindSpellout:
+		return "spellout"
+	case PartKindOrdinal:
+		return "ordinal"
+	default:
+		panic("unreachable")
+	}
+}
+
+// Part is a segment of the formatted output.
+type Part struct {
+	Kind PartKind
+	// Arg is the argument the segment comes from.
+	// It is the zero value if Kind is PartKindLiteral.
+	Arg Argument
+	// Tag is the tag name if Kind is PartKindMarkupStart or PartKindMarkupEnd.
+	Tag   string
+	Value string
+}
+
+// FormatPositionalToParts is like FormatPositional but returns the output as parts.
+func FormatPositionalToParts(tag language.Tag, pattern string, args ...interface{}) (out []Part, err error) {
+	return Options{}.FormatPositionalToParts(tag, pattern, args...)
+}
+
+// FormatNamedToParts is like FormatNamed but returns the output as parts.
+func FormatNamedToParts(tag language.Tag, pattern string, args map[string]interface{}) (out []Part, err error) {
+	return Options{}.FormatNamedToParts(tag, pattern, args)
+}
+
+// FormatPositionalToParts is like FormatPositional but returns the output as parts.
+func (o Options) FormatPositionalToParts(tag language.Tag, pattern string, args ...interface{}) (out []Part, err error) {
+	return o.FormatNamedToParts(tag, pattern, positionalArgs(args))
+}
+
+// FormatNamedToParts is like FormatNamed but returns the output as parts.
+// Adjacent literal parts are merged and empty literal parts are omitted,
+// so concatenating the values of the parts yields the output of FormatNamed.
+// Markup tags are reported as PartKindMarkupStart and PartKindMarkupEnd
+// instead of being rendered with MarkupFuncs.
+func (o Options) FormatNamedToParts(tag language.Tag, pattern string, args map[string]interface{}) (out []Part, err error) {
+	nodes, err := o.Parse(pattern)
+	if err != nil {
+		return
+	}
+
+	tag, err = o.languageTag(tag)
 	if err != nil {
 		return
 	}
 
 	formatter := &textFormatter{
-		Buf:  &strings.Builder{},
-		Tag:  tag,
-		Args: args,
+		Buf:         &strings.Builder{},
+		Tag:         tag,
+		Args:        args,
+		Options:     o,
+		RecordParts: true,
 	}
 
 	err = formatter.Format(nodes, nil)
@@ -39,19 +203,24 @@ func FormatNamed(tag language.Tag, pattern string, args map[string]interface{})
 		return
 	}
 
-	out = formatter.Buf.String()
+	out = formatter.Parts
 	return
 }
 
 type argumentMinusOffset struct {
+	Arg   Argument
 	Name  string
 	Value interface{}
 }
 
 type textFormatter struct {
-	Buf  *strings.Builder
-	Tag  language.Tag
-	Args map[string]interface{}
+	Buf     *strings.Builder
+	Tag     language.Tag
+	Args    map[string]interface{}
+	Options Options
+	// RecordParts tells whether the output is also recorded in Parts.
+	RecordParts bool
+	Parts       []Part
 }
 
 func (f *textFormatter) Format(nodes []Node, argMinusOffset *argumentMinusOffset) (err error) {
@@ -67,12 +236,32 @@ func (f *textFormatter) Format(nodes []Node, argMinusOffset *argumentMinusOffset
 			err = f.FormatTimeArgNode(node)
 		case DatetimeArgNode:
 			err = f.FormatDatetimeArgNode(node)
+		case DateIntervalArgNode:
+			err = f.FormatDateIntervalArgNode(node)
+		case RelativeTimeArgNode:
+			err = f.FormatRelativeTimeArgNode(node)
+		case ListArgNode:
+			err = f.FormatListArgNode(node)
+		case NumberArgNode:
+			err = f.FormatNumberArgNode(node)
+		case CurrencyArgNode:
+			err = f.FormatCurrencyArgNode(node)
+		case DurationArgNode:
+			err = f.FormatDurationArgNode(node)
+		case RuleBasedNumberArgNode:
+			err = f.FormatRuleBasedNumberArgNode(node)
+		case CustomArgNode:
+			err = f.FormatCustomArgNode(node)
 		case SelectArgNode:
 			err = f.FormatSelectArgNode(node)
 		case PluralArgNode:
 			err = f.FormatPluralArgNode(node)
+		case ChoiceArgNode:
+			err = f.FormatChoiceArgNode(node)
 		case PoundNode:
 			err = f.FormatPoundNode(argMinusOffset)
+		case MarkupNode:
+			err = f.FormatMarkupNode(node, argMinusOffset)
 		}
 		if err != nil {
 			return
@@ -105,8 +294,29 @@ func (f *textFormatter) FormatValue(argName string, value interface{}) (out stri
 	return
 }
 
+func (f *textFormatter) Write(kind PartKind, arg Argument, s string) {
+	if f.Options.BidiIsolation && kind != PartKindLiteral {
+		s = bidiIsolate(f.Tag, s)
+	}
+	f.Buf.WriteString(s)
+	if !f.RecordParts {
+		return
+	}
+	if kind == PartKindLiteral {
+		if s == "" {
+			return
+		}
+		if len(f.Parts) > 0 && f.Parts[len(f.Parts)-1].Kind == PartKindLiteral {
+			f.Parts[len(f.Parts)-1].Value += s
+			return
+		}
+		arg = Argument{}
+	}
+	f.Parts = append(f.Parts, Part{Kind: kind, Arg: arg, Value: s})
+}
+
 func (f *textFormatter) FormatTextNode(node TextNode) (err error) {
-	f.Buf.WriteString(node.Value)
+	f.Write(PartKindLiteral, Argument{}, node.Value)
 	return
 }
 
@@ -122,7 +332,18 @@ func (f *textFormatter) FormatNoneArgNode(node NoneArgNode) (err error) {
 		return
 	}
 
-	f.Buf.WriteString(stringValue)
+	if isNumber(argValue) {
+		stringValue, err = localizeDigits(f.Tag, stringValue)
+		if err != nil {
+			return
+		}
+	}
+
+	kind := PartKindArgument
+	if isNumber(argValue) {
+		kind = PartKindNumber
+	}
+	f.Write(kind, node.Arg, stringValue)
 	return
 }
 
@@ -153,7 +374,7 @@ func (f *textFormatter) FormatDateArgNode(node DateArgNode) (err error) {
 		return
 	}
 
-	f.Buf.WriteString(out)
+	f.Write(PartKindDate, node.Arg, out)
 	return
 }
 
@@ -184,7 +405,7 @@ func (f *textFormatter) FormatTimeArgNode(node TimeArgNode) (err error) {
 		return
 	}
 
-	f.Buf.WriteString(out)
+	f.Write(PartKindTime, node.Arg, out)
 	return
 }
 
@@ -215,7 +436,191 @@ func (f *textFormatter) FormatDatetimeArgNode(node DatetimeArgNode) (err error)
 		return
 	}
 
-	f.Buf.WriteString(out)
+	f.Write(PartKindDatetime, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatDateIntervalArgNode(node DateIntervalArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	interval, ok := dateIntervalValue(argValue)
+	if !ok {
+		err = fmt.Errorf("expected %v (%T) to be DateInterval", argName, argValue)
+		return
+	}
+
+	skeleton, err := dateIntervalSkeleton(node.Style)
+	if err != nil {
+		return
+	}
+
+	tz := icu4c.TZName("UTC")
+	out, err := icu4c.FormatDateInterval(f.Tag, tz, skeleton, interval.Start, interval.End)
+	if err != nil {
+		return
+	}
+
+	f.Write(PartKindDateInterval, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatRelativeTimeArgNode(node RelativeTimeArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	d, ok := relativeTimeValue(argValue, f.Options.now)
+	if !ok {
+		err = fmt.Errorf("expected %v (%T) to be time.Duration or time.Time", argName, argValue)
+		return
+	}
+
+	out, err := formatRelativeTime(f.Tag, node.Style, d)
+	if err != nil {
+		return
+	}
+
+	f.Write(PartKindRelativeTime, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatListArgNode(node ListArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	items, ok, err := listItems(f.Tag, argValue)
+	if err != nil {
+		err = fmt.Errorf("%v: %w", argName, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("expected %v (%T) to be []string or []interface{}", argName, argValue)
+		return
+	}
+
+	out, err := formatList(f.Tag, node.Style, items)
+	if err != nil {
+		return
+	}
+
+	f.Write(PartKindList, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatNumberArgNode(node NumberArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	decimal, precision, err := numberDecimal(argValue)
+	if err != nil {
+		err = fmt.Errorf("expected %v (%T) to be a number", argName, argValue)
+		return
+	}
+
+	out, err := formatNumber(f.Tag, node.Style, decimal, precision)
+	if err != nil {
+		return
+	}
+
+	f.Write(PartKindNumber, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatCurrencyArgNode(node CurrencyArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	c, ok := currencyValue(argValue)
+	if !ok {
+		err = fmt.Errorf("expected %v (%T) to be Currency", argName, argValue)
+		return
+	}
+
+	out, err := formatCurrency(f.Tag, node.Style, *c)
+	if err != nil {
+		err = fmt.Errorf("%v: %w", argName, err)
+		return
+	}
+
+	f.Write(PartKindCurrency, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatDurationArgNode(node DurationArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	out, ok, err := formatDurationArgument(f.Tag, node.Style, argValue)
+	if err != nil {
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("expected %v (%T) to be time.Duration or a number", argName, argValue)
+		return
+	}
+
+	f.Write(PartKindDuration, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatRuleBasedNumberArgNode(node RuleBasedNumberArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	decimal, _, err := numberDecimal(argValue)
+	if err != nil {
+		err = fmt.Errorf("expected %v (%T) to be a number", argName, argValue)
+		return
+	}
+
+	out, err := formatRuleBasedNumber(f.Tag, node.Type, node.Style, decimal)
+	if err != nil {
+		return
+	}
+
+	kind := PartKindSpellout
+	if node.Type == "ordinal" {
+		kind = PartKindOrdinal
+	}
+	f.Write(kind, node.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatCustomArgNode(node CustomArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		return
+	}
+
+	out, err := formatCustomValue(f.Tag, node.Type, node.Style, argValue)
+	if err != nil {
+		err = fmt.Errorf("failed to format %v as %v: %w", argName, node.Type, err)
+		return
+	}
+
+	f.Write(PartKindCustom, node.Arg, out)
 	return
 }
 
@@ -259,6 +664,23 @@ func (f *textFormatter) FormatSelectArgNode(node SelectArgNode) (err error) {
 	return
 }
 
+func (f *textFormatter) FormatChoiceArgNode(node ChoiceArgNode) (err error) {
+	argName, argValue, err := f.ResolveArgument(node.Arg)
+	if err != nil {
+		err = nil
+		argValue = 0
+	}
+
+	number, err := choiceNumber(argValue)
+	if err != nil {
+		err = fmt.Errorf("expected %v (%T) to be a number", argName, argValue)
+		return
+	}
+
+	clause := node.Clauses[choiceClauseIndex(node.Clauses, number)]
+	return f.Format(clause.Nodes, nil)
+}
+
 func (f *textFormatter) FormatPluralArgNode(node PluralArgNode) (err error) {
 	argName, argValue, err := f.ResolveArgument(node.Arg)
 	if err != nil {
@@ -272,6 +694,7 @@ func (f *textFormatter) FormatPluralArgNode(node PluralArgNode) (err error) {
 	}
 
 	argumentMinusOffset := &argumentMinusOffset{
+		Arg:   node.Arg,
 		Name:  argName,
 		Value: offsetValue,
 	}
@@ -358,6 +781,41 @@ func (f *textFormatter) FormatPoundNode(argumentMinusOffset *argumentMinusOffset
 	if err != nil {
 		return
 	}
-	f.Buf.WriteString(out)
+	if isDecimalString(out) {
+		out, err = localizeDigits(f.Tag, out)
+		if err != nil {
+			return
+		}
+	}
+	f.Write(PartKindPound, argumentMinusOffset.Arg, out)
+	return
+}
+
+func (f *textFormatter) FormatMarkupNode(node MarkupNode, argMinusOffset *argumentMinusOffset) (err error) {
+	if f.RecordParts {
+		f.Parts = append(f.Parts, Part{Kind: PartKindMarkupStart, Tag: node.Name})
+		err = f.Format(node.Nodes, argMinusOffset)
+		if err != nil {
+			return
+		}
+		f.Parts = append(f.Parts, Part{Kind: PartKindMarkupEnd, Tag: node.Name})
+		return
+	}
+
+	markupFunc, ok := f.Options.MarkupFuncs[node.Name]
+	if !ok {
+		return f.Format(node.Nodes, argMinusOffset)
+	}
+
+	buf := f.Buf
+	f.Buf = &strings.Builder{}
+	err = f.Format(node.Nodes, argMinusOffset)
+	content := f.Buf.String()
+	f.Buf = buf
+	if err != nil {
+		return
+	}
+
+	f.Buf.WriteString(markupFunc(content))
 	return
 }
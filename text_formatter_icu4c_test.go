@@ -0,0 +1,466 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package messageformat
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestFormatNamedDatetime(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else {
+			if !normalizeICUSpacesEqual(actual, expected) {
+				t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+			}
+		}
+	}
+
+	// date arguments.
+	test("{T, date, short}", "11/10/09", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, date, medium}", "Nov 10, 2009", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, date, long}", "November 10, 2009", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, date, full}", "Tuesday, November 10, 2009", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, short}", "11:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, medium}", "11:00:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, long}", "11:00:00 PM UTC", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, time, full}", "11:00:00 PM Coordinated Universal Time", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, short}", "11/10/09, 11:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, medium}", "Nov 10, 2009, 11:00:00 PM", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, long}", "November 10, 2009 at 11:00:00 PM UTC", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+	test("{T, datetime, full}", "Tuesday, November 10, 2009 at 11:00:00 PM Coordinated Universal Time", map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestFormatNamedToPartsDatetime(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected []Part, args map[string]interface{}) {
+		actual, err := FormatNamedToParts(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%v: %#v != %#v\n", pattern, actual, expected)
+		}
+	}
+
+	test("{T, date, short}", []Part{
+		{Kind: PartKindDate, Arg: Argument{Name: "T"}, Value: "11/10/09"},
+	}, map[string]interface{}{
+		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestFormatNamedCalendar(t *testing.T) {
+	test := func(o Options, lang string, expected string) {
+		actual, err := o.FormatNamed(language.Make(lang), "{T, date, full}", map[string]interface{}{
+			"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %q != %q\n", o.Calendar, lang, actual, expected)
+		}
+	}
+
+	// The calendar in the language tag.
+	test(Options{}, "ja-JP", "2009年11月10日火曜日")
+	test(Options{}, "ja-JP-u-ca-japanese", "平成21年11月10日火曜日")
+	test(Options{}, "th-TH-u-ca-buddhist", "วันอังคารที่ 10 พฤศจิกายน พ.ศ. 2552")
+	test(Options{}, "ar-SA-u-ca-islamic", "الثلاثاء، ٢٣ ذو القعدة ١٤٣٠ هـ")
+	test(Options{}, "he-IL-u-ca-hebrew", "יום שלישי, כ״ג בחשוון תש״ע")
+
+	// The calendar in the options.
+	test(Options{Calendar: "japanese"}, "ja-JP", "平成21年11月10日火曜日")
+	test(Options{Calendar: "buddhist"}, "th-TH", "วันอังคารที่ 10 พฤศจิกายน พ.ศ. 2552")
+	test(Options{Calendar: "islamic"}, "ar-SA", "الثلاثاء، ٢٣ ذو القعدة ١٤٣٠ هـ")
+	test(Options{Calendar: "hebrew"}, "en", "Tuesday, 23 Heshvan 5770")
+	test(Options{Calendar: "islamic-umalqura"}, "en", "Tuesday, Dhuʻl-Qiʻdah 22, 1430 AH")
+
+	// The options override the language tag.
+	test(Options{Calendar: "gregory"}, "ja-JP-u-ca-japanese", "2009年11月10日火曜日")
+}
+
+func TestFormatNamedUnicodeExtensions(t *testing.T) {
+	args := map[string]interface{}{
+		"T":   time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		"N":   1234.5,
+		"C":   3,
+		"S":   "123",
+		"M":   Number{Value: 1, MinimumFractionDigits: 2},
+		"NEG": -7,
+	}
+	test := func(lang string, pattern string, expected string) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	// -u-nu- applies to numbers, # and dates, but not to strings.
+	test("en-u-nu-arab", "{N}", "١٢٣٤٫٥")
+	test("en-u-nu-arab", "{C, plural, offset:1 other {# items}}", "٢ items")
+	test("en-u-nu-arab", "{S}", "123")
+	test("en-u-nu-arab", "{M}", "١٫٠٠")
+	// U+061C ARABIC LETTER MARK is written as an escape because it is invisible.
+	test("en-u-nu-arab", "{NEG}", "\u061c-٧")
+	test("fa-u-nu-arabext", "{N}", "۱۲۳۴٫۵")
+	test("en-u-nu-arab-hc-h23", "{T, time, short}", "٢٣:٠٠")
+	// Without -u-nu-, numbers are displayed in ASCII digits as before.
+	test("ar-EG", "{N}", "1234.5")
+
+	// -u-hc- applies to time and datetime.
+	test("en-US-u-hc-h23", "{T, time, short}", "23:00")
+	test("en-US-u-hc-h23", "{T, datetime, short}", "11/10/09, 23:00")
+	test("en-GB-u-hc-h12", "{T, time, short}", "11:00\u202fpm")
+
+	// -u-fw- is passed through.
+	test("en-US-u-fw-mon-hc-h23", "{T, time, short}", "23:00")
+}
+
+func TestFormatNamedToPartsNumberingSystem(t *testing.T) {
+	actual, err := FormatNamedToParts(language.Make("en-u-nu-arab"), "{N} {C, plural, other {#}}", map[string]interface{}{
+		"N": 12,
+		"C": 3,
+	})
+	expected := []Part{
+		{Kind: PartKindNumber, Arg: Argument{Name: "N"}, Value: "١٢"},
+		{Kind: PartKindLiteral, Value: " "},
+		{Kind: PartKindPound, Arg: Argument{Name: "C"}, Value: "٣"},
+	}
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("%#v != %#v\n", actual, expected)
+	}
+}
+
+func TestFormatNamedDateInterval(t *testing.T) {
+	interval := DateInterval{
+		Start: time.Date(2025, time.January, 3, 9, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, time.January, 5, 17, 0, 0, 0, time.UTC),
+	}
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{R, dateinterval, ::yMMMd}", "Jan 3\u2009\u2013\u20095, 2025", map[string]interface{}{"R": interval})
+	test("en", "{R, dateinterval, medium}", "Jan 3\u2009\u2013\u20095, 2025", map[string]interface{}{"R": &interval})
+	test("en", "{R, dateinterval, short}", "1/3/2025\u2009\u2013\u20091/5/2025", map[string]interface{}{"R": interval})
+	test("en", "{R, dateinterval, full}", "Friday, January 3\u2009\u2013\u2009Sunday, January 5, 2025", map[string]interface{}{"R": interval})
+	test("de", "{R, dateinterval, long}", "3.\u20135. Januar 2025", map[string]interface{}{"R": interval})
+	test("en", "Stay: {R, dateinterval, medium}", "Stay: ", map[string]interface{}{})
+
+	_, err := FormatNamed(language.Make("en"), "{R, dateinterval, medium}", map[string]interface{}{
+		"R": interval.Start,
+	})
+	if err == nil || err.Error() != "expected R (time.Time) to be DateInterval" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	parts, err := FormatNamedToParts(language.Make("en"), "{R, dateinterval, medium}", map[string]interface{}{"R": interval})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindDateInterval, Arg: Argument{Name: "R"}, Value: "Jan 3\u2009\u2013\u20095, 2025"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedRelativeTime(t *testing.T) {
+	now := time.Date(2025, time.January, 3, 9, 0, 0, 0, time.UTC)
+	o := Options{
+		Now: func() time.Time { return now },
+	}
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := o.FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	threeDaysAgo := -72 * time.Hour
+	test("en", "{T, relativetime}", "3 days ago", map[string]interface{}{"T": threeDaysAgo})
+	test("en", "{T, relativetime}", "3 days ago", map[string]interface{}{"T": &threeDaysAgo})
+	test("en", "{T, relativetime}", "in 2 hours", map[string]interface{}{"T": now.Add(2 * time.Hour)})
+	test("en", "{T, relativetime, short}", "in 2 hr.", map[string]interface{}{"T": now.Add(2 * time.Hour)})
+	test("en", "{T, relativetime}", "1 day ago", map[string]interface{}{"T": -24 * time.Hour})
+	test("en", "{T, relativetime, auto}", "yesterday", map[string]interface{}{"T": now.Add(-24 * time.Hour)})
+	test("de", "{T, relativetime, auto}", "übermorgen", map[string]interface{}{"T": 48 * time.Hour})
+	test("en", "Seen {T, relativetime}", "Seen ", map[string]interface{}{})
+
+	_, err := o.FormatNamed(language.Make("en"), "{T, relativetime}", map[string]interface{}{"T": 3})
+	if err == nil || err.Error() != "expected T (int) to be time.Duration or time.Time" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	parts, err := o.FormatNamedToParts(language.Make("en"), "{T, relativetime}", map[string]interface{}{"T": threeDaysAgo})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindRelativeTime, Arg: Argument{Name: "T"}, Value: "3 days ago"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedList(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	names := []string{"Alice", "Bob", "Carol"}
+	test("en", "{L, list}", "Alice, Bob, and Carol", map[string]interface{}{"L": names})
+	test("en", "{L, list, conjunction short}", "Alice, Bob, & Carol", map[string]interface{}{"L": names})
+	test("en", "{L, list, disjunction}", "Alice, Bob, or Carol", map[string]interface{}{"L": names})
+	test("en", "{L, list, unit narrow}", "5 ft 2 in", map[string]interface{}{"L": []string{"5 ft", "2 in"}})
+	test("de", "{L, list}", "Alice, Bob und Carol", map[string]interface{}{"L": names})
+	test("en", "{L, list}", "1, 2.5, and x", map[string]interface{}{"L": []interface{}{1, 2.5, "x"}})
+	test("en-u-nu-arab", "{L, list}", "١ and x", map[string]interface{}{"L": []interface{}{1, "x"}})
+	test("en", "Invite {L, list}", "Invite ", map[string]interface{}{})
+
+	_, err := FormatNamed(language.Make("en"), "{L, list}", map[string]interface{}{"L": "Alice"})
+	if err == nil || err.Error() != "expected L (string) to be []string or []interface{}" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+	_, err = FormatNamed(language.Make("en"), "{L, list}", map[string]interface{}{"L": []interface{}{nil}})
+	if err == nil || err.Error() != "L: unsupported list item type: <nil>" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	parts, err := FormatNamedToParts(language.Make("en"), "{L, list}", map[string]interface{}{"L": names})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindList, Arg: Argument{Name: "L"}, Value: "Alice, Bob, and Carol"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedNumber(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{N, number}", "1,234.5", map[string]interface{}{"N": 1234.5})
+	test("de", "{N, number}", "1.234,5", map[string]interface{}{"N": "1234.5"})
+	test("en", "{N, number, integer}", "1,234", map[string]interface{}{"N": 1234.5})
+	test("en", "{N, number, percent}", "25.6%", map[string]interface{}{"N": 0.256})
+	test("en", "{N, number}", "1.00", map[string]interface{}{"N": Number{Value: 1, MinimumFractionDigits: 2}})
+	test("en", "{N, number, ::.0}", "1.2", map[string]interface{}{"N": Number{Value: 1.25, MaximumFractionDigits: 2}})
+	test("en-u-nu-arab", "{N, number}", "١٬٢٣٤٫٥", map[string]interface{}{"N": 1234.5})
+	test("en", "Total: {N, number}", "Total: ", map[string]interface{}{})
+
+	// Measurement units.
+	test("en", "{N, number, ::unit/kilometer unit-width-short}", "5 km", map[string]interface{}{"N": 5})
+	test("en", "{N, number, ::unit/kilometer unit-width-full-name}", "5 kilometers", map[string]interface{}{"N": 5})
+	test("de", "{N, number, ::unit/kilometer unit-width-full-name}", "5 Kilometer", map[string]interface{}{"N": 5})
+	test("en", "{N, number, ::unit/pound}", "3.2 lb", map[string]interface{}{"N": "3.2"})
+	test("en", "{N, number, ::unit/celsius}", "12°C", map[string]interface{}{"N": 12})
+
+	// Unit preferences for the usage of the region.
+	test("en-US", "{N, number, ::unit/kilometer usage/road}", "3.1 mi", map[string]interface{}{"N": 5})
+	test("de-DE", "{N, number, ::unit/kilometer usage/road}", "5 km", map[string]interface{}{"N": 5})
+	test("en-US", "{N, number, ::unit/celsius usage/weather}", "54°F", map[string]interface{}{"N": 12})
+
+	_, err := FormatNamed(language.Make("en"), "{N, number}", map[string]interface{}{"N": "abc"})
+	if err == nil || err.Error() != "expected N (string) to be a number" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+	_, err = FormatNamed(language.Make("en"), "{N, number, ::unit/parsec-per-fortnight}", map[string]interface{}{"N": 1})
+	if err == nil {
+		t.Errorf("expected error\n")
+	}
+
+	parts, err := FormatNamedToParts(language.Make("en"), "{N, number, ::unit/kilometer unit-width-short}", map[string]interface{}{"N": 5})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindNumber, Arg: Argument{Name: "N"}, Value: "5 km"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedCurrency(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	eur := Currency{Value: "1234.5", Code: "EUR"}
+	test("en", "{N, number, ::currency/EUR}", "€1,234.50", map[string]interface{}{"N": "1234.5"})
+	test("de", "{N, number, ::currency/EUR}", "1.234,50\u00a0€", map[string]interface{}{"N": "1234.5"})
+	test("en", "{P, currency}", "€1,234.50", map[string]interface{}{"P": eur})
+	test("de", "{P, currency}", "1.234,50\u00a0€", map[string]interface{}{"P": &eur})
+	test("en", "{P, currency, symbol}", "CA$1,234.50", map[string]interface{}{"P": Currency{Value: "1234.5", Code: "CAD"}})
+	test("en", "{P, currency, narrow}", "$1,234.50", map[string]interface{}{"P": Currency{Value: "1234.5", Code: "CAD"}})
+	test("en", "{P, currency, code}", "EUR\u00a01,234.50", map[string]interface{}{"P": eur})
+	test("ja", "{P, currency}", "￥1,234", map[string]interface{}{"P": Currency{Value: 1234, Code: "JPY"}})
+	test("en", "{P, currency}", "$1", map[string]interface{}{"P": Currency{Value: Number{Value: 1}, Code: "usd"}})
+	test("en", "Total: {P, currency}", "Total: ", map[string]interface{}{})
+
+	// Exact decimals are not rounded through float64.
+	test("en", "{P, currency}", "$123,456,789,012,345,678.01", map[string]interface{}{
+		"P": Currency{Value: "123456789012345678.01", Code: "USD"},
+	})
+
+	// Full names are plural-aware.
+	test("en", "{P, currency, name}", "1 euro", map[string]interface{}{"P": Currency{Value: Number{Value: 1}, Code: "EUR"}})
+	test("en", "{P, currency, name}", "1.00 euros", map[string]interface{}{"P": Currency{Value: 1, Code: "EUR"}})
+	test("fr", "{P, currency, name}", "1,00 euro", map[string]interface{}{"P": Currency{Value: 1, Code: "EUR"}})
+
+	_, err := FormatNamed(language.Make("en"), "{P, currency}", map[string]interface{}{"P": "1.50"})
+	if err == nil || err.Error() != "expected P (string) to be Currency" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+	_, err = FormatNamed(language.Make("en"), "{P, currency}", map[string]interface{}{"P": Currency{Value: 1, Code: "EURO"}})
+	if err == nil || err.Error() != "P: invalid currency code: EURO" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	parts, err := FormatNamedToParts(language.Make("en"), "{P, currency}", map[string]interface{}{"P": eur})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindCurrency, Arg: Argument{Name: "P"}, Value: "€1,234.50"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedDuration(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	d := time.Hour + 5*time.Minute
+	test("en", "{D, duration}", "1 hr, 5 min", map[string]interface{}{"D": d})
+	test("en", "{D, duration, long}", "1 hour, 5 minutes", map[string]interface{}{"D": &d})
+	test("en", "{D, duration, narrow}", "1h 5m", map[string]interface{}{"D": d})
+	test("en", "{D, duration, digital}", "1:05:00", map[string]interface{}{"D": d})
+	test("en", "{D, duration, digital smallest/minute}", "1:06", map[string]interface{}{"D": d + 40*time.Second})
+	test("en", "{D, duration}", "1 day, 2 hr, 30 sec", map[string]interface{}{"D": 26*time.Hour + 30*time.Second})
+	test("en", "{D, duration, largest/hour smallest/minute}", "26 hr, 1 min", map[string]interface{}{"D": 26*time.Hour + 30*time.Second})
+	test("en", "{D, duration, smallest/millisecond}", "1 sec, 500 ms", map[string]interface{}{"D": 1500 * time.Millisecond})
+	test("en", "{D, duration}", "0 sec", map[string]interface{}{"D": time.Duration(0)})
+	test("en", "{D, duration}", "-1 min, 30 sec", map[string]interface{}{"D": -90 * time.Second})
+	test("en", "{D, duration, digital}", "-0:01:30", map[string]interface{}{"D": -90 * time.Second})
+	test("de", "{D, duration, long}", "1 Stunde, 5 Minuten", map[string]interface{}{"D": d})
+	test("en-u-nu-arab", "{D, duration, digital}", "١:٠٥:٠٠", map[string]interface{}{"D": d})
+	test("en", "Took {D, duration}", "Took ", map[string]interface{}{})
+	// Numbers are seconds formatted with RuleBasedNumberFormat.
+	test("en", "{D, duration}", "1:05:00", map[string]interface{}{"D": 3900})
+	test("en", "{D, duration, %with-words}", "1 hour, 5 minutes, 0 seconds", map[string]interface{}{"D": "3900"})
+	test("en", "{D, duration, %with-words}", "1 hour, 5 minutes, 0 seconds", map[string]interface{}{"D": d})
+
+	_, err := FormatNamed(language.Make("en"), "{D, duration}", map[string]interface{}{"D": true})
+	if err == nil || err.Error() != "expected D (bool) to be time.Duration or a number" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	parts, err := FormatNamedToParts(language.Make("en"), "{D, duration}", map[string]interface{}{"D": d})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindDuration, Arg: Argument{Name: "D"}, Value: "1 hr, 5 min"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedRuleBasedNumber(t *testing.T) {
+	test := func(lang string, pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v %v: %+q != %+q\n", lang, pattern, actual, expected)
+		}
+	}
+
+	test("en", "{N, spellout}", "twenty-one", map[string]interface{}{"N": 21})
+	test("en", "{N, spellout}", "one point five", map[string]interface{}{"N": "1.5"})
+	test("en", "{N, ordinal}", "21st", map[string]interface{}{"N": 21})
+	test("en", "{N, spellout, %spellout-ordinal}", "twenty-first", map[string]interface{}{"N": 21})
+	test("de", "{N, spellout}", "ein\u00adund\u00adzwanzig", map[string]interface{}{"N": 21})
+	test("de", "{N, ordinal}", "21.", map[string]interface{}{"N": 21})
+	test("fr", "{N, spellout, %spellout-cardinal-feminine}", "vingt-et-une", map[string]interface{}{"N": 21})
+	test("en", "Rank {N, ordinal}", "Rank ", map[string]interface{}{})
+
+	_, err := FormatNamed(language.Make("en"), "{N, spellout}", map[string]interface{}{"N": "abc"})
+	if err == nil || err.Error() != "expected N (string) to be a number" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	parts, err := FormatNamedToParts(language.Make("en"), "{N, spellout} {N, ordinal}", map[string]interface{}{"N": 2})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindSpellout, Arg: Argument{Name: "N"}, Value: "two"},
+		{Kind: PartKindLiteral, Value: " "},
+		{Kind: PartKindOrdinal, Arg: Argument{Name: "N"}, Value: "2nd"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
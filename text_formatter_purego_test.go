@@ -0,0 +1,128 @@
+//go:build !cgo || purego
+// +build !cgo purego
+
+package messageformat
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/iawaknahc/gomessageformat/icu4c"
+)
+
+func TestFormatNamedDatetimeUnsupported(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string) {
+		_, err := FormatNamed(en, pattern, map[string]interface{}{
+			"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		})
+		if err != icu4c.ErrUnsupported {
+			t.Errorf("%v: unexpected error: %v\n", pattern, err)
+		}
+	}
+
+	test("{T, date, short}")
+	test("{T, time, short}")
+	test("{T, datetime, short}")
+
+	_, err := FormatNamed(en, "{R, dateinterval, medium}", map[string]interface{}{
+		"R": DateInterval{},
+	})
+	if err != icu4c.ErrUnsupported {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	_, err = FormatNamed(en, "{D, relativetime}", map[string]interface{}{
+		"D": -time.Hour,
+	})
+	if err != icu4c.ErrUnsupported {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	_, err = FormatNamed(en, "{L, list}", map[string]interface{}{
+		"L": []string{"a", "b"},
+	})
+	if err != icu4c.ErrUnsupported {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	_, err = FormatNamed(en, "{N, number}", map[string]interface{}{
+		"N": 1,
+	})
+	if err != icu4c.ErrUnsupported {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	_, err = FormatNamed(en, "{P, currency}", map[string]interface{}{
+		"P": Currency{Value: "1.50", Code: "EUR"},
+	})
+	if !errors.Is(err, icu4c.ErrUnsupported) {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	_, err = FormatNamed(en, "{D, duration}", map[string]interface{}{
+		"D": time.Hour,
+	})
+	if err != icu4c.ErrUnsupported {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	_, err = FormatNamed(en, "{N, spellout}", map[string]interface{}{
+		"N": 21,
+	})
+	if err != icu4c.ErrUnsupported {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+
+	// select and plural do not need icu4c.
+	actual, err := FormatNamed(en, "{N, plural, one {# cat} other {# cats}}", map[string]interface{}{
+		"N": 2,
+	})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if actual != "2 cats" {
+		t.Errorf("%v != 2 cats\n", actual)
+	}
+}
+
+func TestFormatNamedNumberingSystemUnsupported(t *testing.T) {
+	pattern := "{N}, {N, plural, other {# cats}}"
+	args := map[string]interface{}{
+		"N": 1234.5,
+	}
+
+	// latn displays the digits as is.
+	test := func(lang string, expected string) {
+		actual, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != nil {
+			t.Errorf("%v: err: %v\n", lang, err)
+		} else if actual != expected {
+			t.Errorf("%v: %v != %v\n", lang, actual, expected)
+		}
+	}
+
+	test("en-u-nu-latn", "1234.5, 1234.5 cats")
+	test("ar-MA-u-nu-latn", "1234.5, 1234.5 cats")
+	test("ks-Deva-u-nu-latn", "1234.5, 1234.5 cats")
+
+	if actual, ok := executeTemplate(t, "en-u-nu-latn", pattern, args); ok && actual != "1234.5, 1234.5 cats" {
+		t.Errorf("%v != 1234.5, 1234.5 cats\n", actual)
+	}
+
+	// Other numbering systems need icu4c.
+	testUnsupported := func(lang string) {
+		_, err := FormatNamed(language.Make(lang), pattern, args)
+		if err != icu4c.ErrUnsupported {
+			t.Errorf("%v: unexpected error: %v\n", lang, err)
+		}
+	}
+
+	testUnsupported("en-u-nu-arab")
+	testUnsupported("ar-u-nu-arab")
+	testUnsupported("fa-IR-u-nu-arabext")
+	testUnsupported("ar-MA-u-nu-arab")
+	testUnsupported("ks-Deva-u-nu-arabext")
+}
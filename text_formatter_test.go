@@ -2,8 +2,9 @@ package messageformat
 
 import (
 	"fmt"
+	"math/big"
+	"reflect"
 	"testing"
-	"time"
 
 	"golang.org/x/text/language"
 )
@@ -43,44 +44,6 @@ func TestFormatNamed(t *testing.T) {
 		"ME":  "Jane",
 	})
 
-	// date arguments.
-	test("{T, date, short}", "11/10/09", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, date, medium}", "Nov 10, 2009", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, date, long}", "November 10, 2009", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, date, full}", "Tuesday, November 10, 2009", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, short}", "11:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, medium}", "11:00:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, long}", "11:00:00 PM UTC", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, time, full}", "11:00:00 PM Coordinated Universal Time", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, short}", "11/10/09, 11:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, medium}", "Nov 10, 2009, 11:00:00 PM", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, long}", "November 10, 2009 at 11:00:00 PM UTC", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-	test("{T, datetime, full}", "Tuesday, November 10, 2009 at 11:00:00 PM Coordinated Universal Time", map[string]interface{}{
-		"T": time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
-	})
-
 	// Simple select
 	test(`{GENDER, select,
 				male {He jumps over the lazy dog}
@@ -465,6 +428,33 @@ func TestFormatPositional(t *testing.T) {
 	test(pattern, "Sam invites Alex and 2 other people to their party.", gender, 3, host, guest)
 }
 
+func TestFormatPositionalChoice(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected string, args ...interface{}) {
+		actual, err := FormatPositional(en, pattern, args...)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	pattern := "{0, choice, 0#no files|1#one file|1<{0} files}"
+	test(pattern, "no files", 0)
+	test(pattern, "one file", 1)
+	test(pattern, "2 files", 2)
+	test(pattern, "1.5 files", 1.5)
+	test(pattern, "no files", -1)
+	test(pattern, "one file", "1.0")
+	test(pattern, "no files")
+	test("{0, choice, 0#none|1#{1, select, a {A} other {B}}}", "A", 1, "a")
+
+	_, err := FormatPositional(en, pattern, "abc")
+	if err == nil || err.Error() != "expected 0 (string) to be a number" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}
+
 func TestTextUnknownArgument(t *testing.T) {
 	en := language.Make("en")
 	test := func(pattern string, expected string, args map[string]interface{}) {
@@ -484,6 +474,266 @@ func TestTextUnknownArgument(t *testing.T) {
 	test("Hello {T, datetime, short} Hello", "Hello  Hello", nil)
 	test("Hello {GENDER, select, male {he} female {she} other {they}}", "Hello they", nil)
 	test("Hello {COUNT, plural, one {# cat} other {# cats}}", "Hello 0 cats", nil)
+	test("Hello {PRICE, money} Hello", "Hello  Hello", nil)
+}
+
+func TestFormatNamedToParts(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected []Part, args map[string]interface{}) {
+		actual, err := FormatNamedToParts(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%v: %#v != %#v\n", pattern, actual, expected)
+		}
+	}
+
+	test("Hello", []Part{
+		{Kind: PartKindLiteral, Value: "Hello"},
+	}, nil)
+
+	test("Hello {NAME}, you have {COUNT} messages", []Part{
+		{Kind: PartKindLiteral, Value: "Hello "},
+		{Kind: PartKindArgument, Arg: Argument{Name: "NAME"}, Value: "John"},
+		{Kind: PartKindLiteral, Value: ", you have "},
+		{Kind: PartKindNumber, Arg: Argument{Name: "COUNT"}, Value: "3"},
+		{Kind: PartKindLiteral, Value: " messages"},
+	}, map[string]interface{}{
+		"NAME":  "John",
+		"COUNT": 3,
+	})
+
+	// Literals from the pattern and from the selected clause are merged.
+	test("Hello {GENDER, select, male {sir} other {there}}!", []Part{
+		{Kind: PartKindLiteral, Value: "Hello sir!"},
+	}, map[string]interface{}{
+		"GENDER": "male",
+	})
+
+	test("{COUNT, plural, offset:1 one {# other cat} other {# other cats}}", []Part{
+		{Kind: PartKindPound, Arg: Argument{Name: "COUNT"}, Value: "2"},
+		{Kind: PartKindLiteral, Value: " other cats"},
+	}, map[string]interface{}{
+		"COUNT": 3,
+	})
+}
+
+func TestFormatPositionalToParts(t *testing.T) {
+	actual, err := FormatPositionalToParts(language.Make("en"), "{0} and {1}", "cats", 2)
+	if err != nil {
+		t.Fatalf("err: %v\n", err)
+	}
+	expected := []Part{
+		{Kind: PartKindArgument, Arg: Argument{Index: 0}, Value: "cats"},
+		{Kind: PartKindLiteral, Value: " and "},
+		{Kind: PartKindNumber, Arg: Argument{Index: 1}, Value: "2"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("%#v != %#v\n", actual, expected)
+	}
+}
+
+func TestFormatNamedMarkup(t *testing.T) {
+	en := language.Make("en")
+	options := Options{
+		Markup: true,
+		MarkupFuncs: map[string]MarkupFunc{
+			"b": func(content string) string {
+				return "**" + content + "**"
+			},
+		},
+	}
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		actual, err := options.FormatNamed(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	test("Hello <b>{NAME}</b>!", "Hello **John**!", map[string]interface{}{
+		"NAME": "John",
+	})
+	test("{COUNT, plural, other {<b>#</b> cats}}", "**3** cats", map[string]interface{}{
+		"COUNT": 3,
+	})
+	// A tag without a function outputs its content.
+	test("<i>Hello</i> <b><i>{NAME}</i></b>", "Hello **John**", map[string]interface{}{
+		"NAME": "John",
+	})
+	test("a<b <b/>", "a<b ****", nil)
+
+	actual, err := options.FormatNamedToParts(en, "Hi <b>{NAME}</b>!", map[string]interface{}{
+		"NAME": "John",
+	})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else {
+		expected := []Part{
+			{Kind: PartKindLiteral, Value: "Hi "},
+			{Kind: PartKindMarkupStart, Tag: "b"},
+			{Kind: PartKindArgument, Arg: Argument{Name: "NAME"}, Value: "John"},
+			{Kind: PartKindMarkupEnd, Tag: "b"},
+			{Kind: PartKindLiteral, Value: "!"},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%#v != %#v\n", actual, expected)
+		}
+	}
+}
+
+func TestFormatNamedBidiIsolation(t *testing.T) {
+	he := language.Make("he")
+	test := func(options Options, pattern string, expected string, args map[string]interface{}) {
+		actual, err := options.FormatNamed(he, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	args := map[string]interface{}{
+		"NAME":  "John",
+		"COUNT": 3,
+	}
+	test(Options{}, "שלום {NAME}!", "שלום John!", args)
+	test(Options{BidiIsolation: true}, "שלום {NAME}!", "שלום \u2068John\u2069!", args)
+	test(Options{BidiIsolation: true}, "{COUNT} הודעות", "3 הודעות", args)
+	test(Options{BidiIsolation: true}, "{COUNT, plural, other {# הודעות}}", "3 הודעות", args)
+	test(Options{BidiIsolation: true}, "{COUNT, plural, other {# {NAME}}}", "3 \u2068John\u2069", args)
+	// Formatted arguments are isolated too.
+	test(Options{BidiIsolation: true}, "מחיר: {COUNT, money}", "מחיר: \u2068USD 3 (he)\u2069", args)
+
+	parts, err := Options{BidiIsolation: true}.FormatNamedToParts(he, "{COUNT, money}", args)
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if !reflect.DeepEqual(parts, []Part{
+		{Kind: PartKindCustom, Arg: Argument{Name: "COUNT"}, Value: "\u2068USD 3 (he)\u2069"},
+	}) {
+		t.Errorf("%#v\n", parts)
+	}
+}
+
+func TestFormatNamedNamedTypes(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	test("User {ID}", "User 42", map[string]interface{}{
+		"ID": testUserID(42),
+	})
+	test("{GENDER, select, female {She} other {They}}", "She", map[string]interface{}{
+		"GENDER": testGender("female"),
+	})
+	test("{COUNT, plural, =1 {one cat} other {# cats}}", "one cat", map[string]interface{}{
+		"COUNT": testUserID(1),
+	})
+	test("{COUNT, plural, offset:1 one {# cat} other {# cats}}", "1 cat", map[string]interface{}{
+		"COUNT": testUserID(2),
+	})
+	test("Hello {NAME}", "Hello John", map[string]interface{}{
+		"NAME": testStringer{"John"},
+	})
+	test("{COUNT, plural, one {# cat} other {# cats}}", "1 cat", map[string]interface{}{
+		"COUNT": testTextMarshaler{Value: "1"},
+	})
+}
+
+func TestFormatNamedBigNumbers(t *testing.T) {
+	en := language.Make("en")
+	test := func(pattern string, expected string, args map[string]interface{}) {
+		actual, err := FormatNamed(en, pattern, args)
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", pattern, actual, expected)
+		}
+	}
+
+	i, _ := new(big.Int).SetString("100000000000000000001", 10)
+	test("{COUNT, plural, offset:1 one {# file} other {# files}}", "100000000000000000000 files", map[string]interface{}{
+		"COUNT": i,
+	})
+	test("{COUNT, plural, =1 {one file} other {# files}}", "one file", map[string]interface{}{
+		"COUNT": big.NewInt(1),
+	})
+	test("{COUNT, plural, one {# file} other {# files}}", "1.00000000000000000001 files", map[string]interface{}{
+		"COUNT": testDecimal("1.00000000000000000001"),
+	})
+	test("{COUNT, plural, one {# file} other {# files}}", "1.5 files", map[string]interface{}{
+		"COUNT": big.NewRat(3, 2),
+	})
+	test("{COUNT, plural, offset:1 other {# files}}", "0.50 files", map[string]interface{}{
+		"COUNT": "1.50",
+	})
+	test("{COUNT}", "12345678901234567890.125", map[string]interface{}{
+		"COUNT": testDecimal("12345678901234567890.125"),
+	})
+}
+
+func TestFormatNamedCompact(t *testing.T) {
+	defer SetPluralRules(XTextPluralRules{})
+	SetPluralRules(CLDRPluralRules{})
+
+	pattern := "{N, plural, one {# utilisateur} many {# de utilisateurs} other {# utilisateurs}}"
+	test := func(n interface{}, expected string) {
+		actual, err := FormatNamed(language.Make("fr"), pattern, map[string]interface{}{
+			"N": n,
+		})
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != expected {
+			t.Errorf("%v: %q != %q\n", n, actual, expected)
+		}
+	}
+
+	test(1, "1 utilisateur")
+	test(2, "2 utilisateurs")
+	test("1.2c6", "1.2c6 de utilisateurs")
+	test("1.2e6", "1.2e6 de utilisateurs")
+	test(1000000, "1000000 de utilisateurs")
+	test(1200000, "1200000 utilisateurs")
+
+	actual, err := FormatNamed(language.Make("fr"), "{N}, {N, plural, offset:1 other {#}}", map[string]interface{}{
+		"N": "1.2c6",
+	})
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if actual != "1.2c6, 1199999" {
+		t.Errorf("%q != %q\n", actual, "1.2c6, 1199999")
+	}
+
+	// Strings like codes are not numbers outside plural.
+	for _, c := range []string{"2e5", "3c2"} {
+		actual, err := FormatNamed(language.English, "code {c}", map[string]interface{}{
+			"c": c,
+		})
+		if err != nil {
+			t.Errorf("err: %v\n", err)
+		} else if actual != "code "+c {
+			t.Errorf("%q != %q\n", actual, "code "+c)
+		}
+	}
+}
+
+func TestFormatNamedCustom(t *testing.T) {
+	actual, err := FormatNamed(language.Make("en"), "It costs {PRICE, money}, or {PRICE, money, EUR}.", map[string]interface{}{
+		"PRICE": 42,
+	})
+	expected := "It costs USD 42 (en), or EUR 42 (en)."
+	if err != nil {
+		t.Errorf("err: %v\n", err)
+	} else if actual != expected {
+		t.Errorf("%q != %q\n", actual, expected)
+	}
 }
 
 func ExampleFormatPositional() {
@@ -503,3 +753,10 @@ func ExampleFormatPositional() {
 	fmt.Printf("%s\n", out)
 	// Output: There is only 1 file on disk.
 }
+
+func TestOptionsCalendarError(t *testing.T) {
+	_, err := Options{Calendar: "not a calendar"}.FormatNamed(language.Make("en"), "Hello", nil)
+	if err == nil || err.Error() != "invalid calendar: not a calendar" {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+}